@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/i-sub135/go-rest-blueprint/source/config"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/db"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// bootstrap is the one startup path shared by every subcommand:
+// config.LoadConfig and config.Validate, then logger.Init, then db.Init when withDB is set.
+func bootstrap(configPath string, withDB bool) (*config.Config, *gorm.DB, error) {
+	if err := config.LoadConfig(configPath); err != nil {
+		return nil, nil, fmt.Errorf("invalid config:\n%w", err)
+	}
+	cfg := config.GetConfig()
+	if err := config.Validate(cfg); err != nil {
+		return nil, nil, fmt.Errorf("invalid config:\n%w", err)
+	}
+
+	logger.Init(cfg.Log.PrettyConsole)
+
+	if !withDB {
+		return cfg, nil, nil
+	}
+
+	database, err := db.Init()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to connect to database")
+		return nil, nil, err
+	}
+	return cfg, database, nil
+}
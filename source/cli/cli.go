@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/i-sub135/go-rest-blueprint/source/config"
+)
+
+const defaultConfigPath = "config.yaml"
+
+type command struct {
+	usage string
+	run   func(configPath string, args []string) error
+}
+
+var commands = map[string]command{
+	"serve":       {usage: "start the HTTP server (default)", run: runServe},
+	"migrate":     {usage: "up|down|status|to <version>", run: runMigrate},
+	"seed":        {usage: "[users|customers|all] [-users n] [-customers n]", run: runSeed},
+	"routes":      {usage: "list mounted HTTP routes", run: runRoutes},
+	"config":      {usage: "print|validate", run: runConfig},
+	"healthcheck": {usage: "probe /readyz of a running server, exit 1 when unhealthy", run: runHealthcheck},
+}
+
+// Run parses the global flags and dispatches to a subcommand, returning the process exit code.
+//
+//	go-blueprint [--config path] [--strict-config=false] [--set key=value]... <command> [args]
+func Run(args []string) int {
+	fs := flag.NewFlagSet("go-blueprint", flag.ContinueOnError)
+	configPath := fs.String("config", defaultConfigPath, "path to YAML config file")
+	fs.BoolVar(&config.Strict, "strict-config", true, "reject config file keys that are not declared")
+	fs.Func("set", "override a config key, key=value; repeatable, wins over files and env", func(s string) error {
+		key, value, ok := strings.Cut(s, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("%q is not key=value", s)
+		}
+		config.Overrides[key] = value
+		return nil
+	})
+	fs.Usage = func() { usage(fs.Output()) }
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	name, rest := "serve", fs.Args()
+	if len(rest) > 0 {
+		name, rest = rest[0], rest[1:]
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		usage(os.Stderr)
+		return 2
+	}
+
+	if err := cmd.run(*configPath, rest); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return 1
+	}
+	return 0
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: go-blueprint [--config path] [--strict-config=false] [--set key=value]... <command> [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-12s %s\n", name, commands[name].usage)
+	}
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/i-sub135/go-rest-blueprint/source/config"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/redact"
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/v2"
+)
+
+const configUsage = "usage: config print [--sources]|validate"
+
+func runConfig(configPath string, args []string) error {
+	if len(args) == 0 {
+		return errors.New(configUsage)
+	}
+
+	if err := config.LoadConfig(configPath); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "print":
+		fs := flag.NewFlagSet("config print", flag.ContinueOnError)
+		withSources := fs.Bool("sources", false, "list every key with the layer its value came from")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *withSources {
+			return printSources(redactSecrets(config.Effective()))
+		}
+		out, err := redactSecrets(config.Effective()).Marshal(yaml.Parser())
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(out)
+		return err
+	case "validate":
+		if err := config.Validate(config.GetConfig()); err != nil {
+			return err
+		}
+		fmt.Println("config OK")
+		return nil
+	default:
+		return errors.New(configUsage)
+	}
+}
+
+// printSources lists the flattened keys of k with their value and source,
+// e.g. "app.port  9000  env APP_PORT".
+func printSources(k *koanf.Koanf) error {
+	cfg := config.GetConfig()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+	for _, key := range k.Keys() { // sorted
+		fmt.Fprintf(w, "%s\t%v\t%s\n", key, k.Get(key), cfg.Source(key))
+	}
+	return w.Flush()
+}
+
+// redactSecrets masks credentials so printed config can be pasted into
+// tickets and chat.
+func redactSecrets(k *koanf.Koanf) *koanf.Koanf {
+	if dsn := k.String("db.dsn"); dsn != "" {
+		k.Set("db.dsn", redact.DSN(dsn))
+	}
+	if replicas := k.Strings("db.replicas"); len(replicas) > 0 {
+		for i, dsn := range replicas {
+			replicas[i] = redact.DSN(dsn)
+		}
+		k.Set("db.replicas", replicas)
+	}
+	for _, key := range k.Keys() {
+		name := key[strings.LastIndex(key, ".")+1:]
+		if (strings.Contains(name, "secret") || strings.Contains(name, "password")) && k.String(key) != "" {
+			k.Set(key, redact.Redacted)
+		}
+	}
+	return k
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/i-sub135/go-rest-blueprint/source/config"
+)
+
+// runHealthcheck probes a running server, meant for container HEALTHCHECK instructions.
+func runHealthcheck(configPath string, args []string) error {
+	fs := flag.NewFlagSet("healthcheck", flag.ContinueOnError)
+	url := fs.String("url", "", "health URL (default http://127.0.0.1:<app.port>/readyz)")
+	timeout := fs.Duration("timeout", 5*time.Second, "request timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *url == "" {
+		if err := config.LoadConfig(configPath); err != nil {
+			return err
+		}
+		*url = fmt.Sprintf("http://127.0.0.1:%d/readyz", config.GetConfig().App.Port)
+	}
+
+	client := &http.Client{Timeout: *timeout}
+	resp, err := client.Get(*url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", *url, resp.Status)
+	}
+	fmt.Println("healthy")
+	return nil
+}
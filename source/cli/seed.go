@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/db"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/seed"
+)
+
+// runSeed inserts sample data; tables must already exist (`migrate up`).
+func runSeed(configPath string, args []string) error {
+	target := "all"
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		target, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	users := fs.Int("users", 100, "number of users to generate")
+	customers := fs.Int("customers", 50, "number of customers to generate")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if target != "all" && target != "users" && target != "customers" {
+		return fmt.Errorf("unknown seed target %q, want users, customers or all", target)
+	}
+
+	_, database, err := bootstrap(configPath, true)
+	if err != nil {
+		return err
+	}
+	defer db.Close(database)
+
+	ctx := context.Background()
+	if target == "all" || target == "users" {
+		seed.Users(ctx, database, *users)
+	}
+	if target == "all" || target == "customers" {
+		seed.Customers(ctx, database, *customers)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/i-sub135/go-rest-blueprint/source/config"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/public/healtcheck"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/db"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/health"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/lifecycle"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/metrics"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/tracing"
+	"github.com/i-sub135/go-rest-blueprint/source/service"
+	"github.com/i-sub135/go-rest-blueprint/source/service/middleware"
+	"gorm.io/gorm"
+)
+
+func runServe(configPath string, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, database, err := bootstrap(configPath, true)
+	if err != nil {
+		return err
+	}
+
+	// lifecycle manager, hooks run in reverse order: database closes before logger flush
+	lc := lifecycle.New(cfg.HTTP.ShutdownTimeout, cfg.HTTP.DrainDelay)
+	lc.OnShutdown("logger", func(ctx context.Context) error { return logger.Flush() })
+	lc.OnShutdown("database", func(ctx context.Context) error { return db.Close(database) })
+
+	// a failed startup releases whatever the hooks registered so far cover
+	abort := func(err error) error { return errors.Join(err, lc.Abort()) }
+
+	if err := config.ValidateServe(cfg); err != nil {
+		return abort(fmt.Errorf("invalid config:\n%w", err))
+	}
+	if err := instrument(cfg, database, lc); err != nil {
+		return abort(err)
+	}
+	reloadLogLevelsOnSIGHUP(configPath, lc)
+
+	authn, err := newAuthn(cfg)
+	if err != nil {
+		return abort(err)
+	}
+	healthHandler := healtcheck.NewHandler(database, lc, newHealthChecks(cfg, database))
+	engine, err := newEngine(cfg, database, healthHandler, authn)
+	if err != nil {
+		return abort(err)
+	}
+
+	svc := &http.Server{
+		Addr:           fmt.Sprintf(":%v", cfg.App.Port),
+		Handler:        engine,
+		ReadTimeout:    10 * time.Second,
+		WriteTimeout:   10 * time.Second,
+		IdleTimeout:    120 * time.Second,
+		MaxHeaderBytes: 1 << 20,
+	}
+	lc.AddServer("http", svc)
+
+	if cfg.Admin.Enabled {
+		lc.AddServer("admin", &http.Server{
+			Addr:              fmt.Sprintf(":%v", cfg.Admin.Port),
+			Handler:           newAdminEngine(healthHandler),
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+		})
+	}
+
+	logger.Info().Str("mode", cfg.App.Mode).Msgf("listening on port %v", cfg.App.Port)
+	return lc.Run()
+}
+
+// reloadLogLevelsOnSIGHUP re-reads the config on SIGHUP and applies its log
+// levels, ending any temporary change made through the admin endpoint.
+func reloadLogLevelsOnSIGHUP(configPath string, lc *lifecycle.Manager) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	lc.OnShutdown("sighup", func(ctx context.Context) error {
+		signal.Stop(hup)
+		close(hup)
+		return nil
+	})
+
+	go func() {
+		for range hup {
+			cfg, err := config.Load(configPath)
+			if err != nil {
+				logger.Error().Err(err).Msg("sighup: config not reloaded")
+				continue
+			}
+			levels, err := logger.ParseLevels(cfg.Log.Level, cfg.Log.Modules)
+			if err != nil {
+				logger.Error().Err(err).Msg("sighup: invalid log levels, keeping the current ones")
+				continue
+			}
+			logger.SetLevels(levels, 0)
+			logger.Warn().Str("level", cfg.Log.Level).Interface("modules", cfg.Log.Modules).Msg("sighup: log levels reloaded")
+		}
+	}()
+}
+
+// instrument sets up tracing, flushed on shutdown before the database
+// closes, and the tracing and metrics gorm plugins.
+func instrument(cfg *config.Config, database *gorm.DB, lc *lifecycle.Manager) error {
+	shutdownTracing, err := tracing.Init(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	lc.OnShutdown("tracing", shutdownTracing)
+
+	if err := database.Use(tracing.GormPlugin{}); err != nil {
+		return err
+	}
+	return metrics.InstrumentDB(database)
+}
+
+// newEngine builds the gin engine with middleware and every route mounted,
+// authn being the JWT middleware from newAuthn.
+func newEngine(cfg *config.Config, database *gorm.DB, healthHandler *healtcheck.Handler, authn gin.HandlerFunc) (*gin.Engine, error) {
+	gin.SetMode(cfg.App.Mode) // Set mode first
+	r := gin.New()
+	// only these proxies may set the client IP through X-Forwarded-For
+	if err := r.SetTrustedProxies(cfg.HTTP.Security.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("http.security.trusted_proxies: %w", err)
+	}
+	r.Use(middleware.RequestIDMiddleware())
+	r.Use(middleware.Tracing())
+	r.Use(middleware.Metrics())
+	r.Use(logger.GinZLogger())
+	r.Use(gin.Recovery())
+	r.Use(middleware.SecurityHeaders(cfg))
+	r.Use(middleware.CORS(cfg))
+	r.Use(middleware.ReadYourWritesMiddleware())
+
+	r.GET("/livez", healthHandler.Livez)
+	r.GET("/readyz", healthHandler.Readyz)
+	r.GET("/health", healthHandler.HealtCheck)
+
+	// Mounting routers
+	route_api_v1 := r.Group("/api/v1")
+	mounthRoute := service.NewRouters(database, cfg, authn)
+	mounthRoute.MountRouters(route_api_v1)
+
+	return r, nil
+}
+
+// newHealthChecks registers the dependency checks: the primary database is
+// critical, ejected replicas only show in the details since reads fall back
+// to the primary.
+func newHealthChecks(cfg *config.Config, database *gorm.DB) *health.Registry {
+	checks := health.NewRegistry()
+	checks.Register(health.Check{
+		Name:     "database",
+		Check:    func(ctx context.Context) error { return db.Ping(ctx, database) },
+		Timeout:  cfg.Health.Timeout,
+		Critical: true,
+		CacheTTL: cfg.Health.CacheTTL,
+	})
+	if database == nil {
+		return checks // routes command
+	}
+	if resolver := db.ResolverOf(database); resolver != nil {
+		checks.Register(health.Check{
+			Name:    "database_replicas",
+			Check:   resolver.Check,
+			Timeout: cfg.Health.Timeout,
+		})
+	}
+	return checks
+}
+
+// newAdminEngine builds the engine of the admin listener: metrics and the
+// health details, whose errors and pool statistics are for operators only.
+func newAdminEngine(healthHandler *healtcheck.Handler) *gin.Engine {
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+	r.GET("/health/details", healthHandler.Details)
+	return r
+}
+
+// newAuthn returns the JWT middleware, or one granting every request the
+// anonymous principal when auth.enabled is false.
+func newAuthn(cfg *config.Config) (gin.HandlerFunc, error) {
+	if !cfg.Auth.Enabled {
+		logger.Warn().Msg("auth disabled, every request runs as anonymous with all scopes")
+		return middleware.JWTAuth(nil), nil
+	}
+	verifier, err := auth.NewJWTVerifier(auth.JWTOptions{
+		HMACSecret:    cfg.Auth.JWT.HMACSecret,
+		PublicKeyFile: cfg.Auth.JWT.PublicKeyFile,
+		JWKSFile:      cfg.Auth.JWT.JWKSFile,
+		Issuer:        cfg.Auth.JWT.Issuer,
+		Audience:      cfg.Auth.JWT.Audience,
+		Leeway:        cfg.Auth.JWT.Leeway,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return middleware.JWTAuth(verifier), nil
+}
@@ -0,0 +1,191 @@
+package apperror
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	validationutils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/validation_utils"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type Kind string
+
+const (
+	KindBadRequest   Kind = "bad_request"
+	KindValidation   Kind = "validation_failed"
+	KindNotFound     Kind = "not_found"
+	KindConflict     Kind = "conflict"
+	KindUnauthorized Kind = "unauthorized"
+	KindForbidden    Kind = "forbidden"
+	KindTooMany      Kind = "too_many_requests"
+	KindUnavailable  Kind = "service_unavailable"
+	KindInternal     Kind = "internal_error"
+	KindCanceled     Kind = "client_closed_request"
+)
+
+// StatusClientClosedRequest is the nginx code for a request the client gave
+// up on; nobody reads the answer, it only shows in logs and metrics.
+const StatusClientClosedRequest = 499
+
+// StatusText is http.StatusText knowing StatusClientClosedRequest.
+func StatusText(status int) string {
+	if status == StatusClientClosedRequest {
+		return "Client Closed Request"
+	}
+	return http.StatusText(status)
+}
+
+// HTTPStatus returns the status code a Kind is answered with.
+func (k Kind) HTTPStatus() int {
+	switch k {
+	case KindBadRequest:
+		return http.StatusBadRequest
+	case KindValidation:
+		return http.StatusUnprocessableEntity
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindTooMany:
+		return http.StatusTooManyRequests
+	case KindUnavailable:
+		return http.StatusServiceUnavailable
+	case KindCanceled:
+		return StatusClientClosedRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Error is a domain error. Message and Fields are safe to show to clients;
+// the wrapped cause is only logged.
+type Error struct {
+	Kind    Kind
+	Code    string // machine readable, defaults to the Kind
+	Message string
+	Fields  []validationutils.FieldError
+	cause   error
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error { return e.cause }
+
+// Is matches errors of the same kind and code, so sentinel values such as
+// ErrUserNotFound keep working with errors.Is after Wrap.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind && t.Code == e.Code
+}
+
+// Wrap returns a copy of e carrying cause for logging.
+func (e *Error) Wrap(cause error) *Error {
+	cp := *e
+	cp.cause = cause
+	return &cp
+}
+
+// WithCode returns a copy of e with a more specific code, e.g. "email_taken".
+func (e *Error) WithCode(code string) *Error {
+	cp := *e
+	cp.Code = code
+	return &cp
+}
+
+func newError(kind Kind, msg string) *Error {
+	return &Error{Kind: kind, Code: string(kind), Message: msg}
+}
+
+func BadRequest(msg string) *Error   { return newError(KindBadRequest, msg) }
+func NotFound(msg string) *Error     { return newError(KindNotFound, msg) }
+func Conflict(msg string) *Error     { return newError(KindConflict, msg) }
+func Unauthorized(msg string) *Error { return newError(KindUnauthorized, msg) }
+func Forbidden(msg string) *Error    { return newError(KindForbidden, msg) }
+func TooMany(msg string) *Error      { return newError(KindTooMany, msg) }
+func Unavailable(msg string) *Error  { return newError(KindUnavailable, msg) }
+func Internal(msg string) *Error     { return newError(KindInternal, msg) }
+func Canceled(msg string) *Error     { return newError(KindCanceled, msg) }
+
+func Validation(msg string, fields []validationutils.FieldError) *Error {
+	e := newError(KindValidation, msg)
+	e.Fields = fields
+	return e
+}
+
+// PostgreSQL SQLSTATE codes we translate, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation      = "23505"
+	pgForeignKeyViolation  = "23503"
+	pgNotNullViolation     = "23502"
+	pgCheckViolation       = "23514"
+	pgQueryCanceled        = "57014"
+	pgTooManyConnections   = "53300"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+// From converts any error into an *Error: domain errors pass through, gorm,
+// pgconn and context errors are translated, everything else is Internal.
+// The original error is kept as the cause.
+func From(err error) *Error {
+	var ae *Error
+	if errors.As(err, &ae) {
+		return ae
+	}
+
+	if fields, ok := validationutils.FieldErrors(err); ok {
+		return Validation("validation failed", fields).Wrap(err)
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NotFound("resource not found").Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return Conflict("resource already exists").Wrap(err)
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return Conflict("resource is referenced by or references a missing resource").Wrap(err)
+	case errors.Is(err, gorm.ErrCheckConstraintViolated):
+		return Validation("value violates a constraint", nil).Wrap(err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return Unavailable("request timed out").Wrap(err)
+	case errors.Is(err, context.Canceled):
+		return Canceled("request canceled by the client").Wrap(err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			return Conflict("resource already exists").Wrap(err)
+		case pgForeignKeyViolation:
+			return Conflict("resource is referenced by or references a missing resource").Wrap(err)
+		case pgNotNullViolation, pgCheckViolation:
+			return Validation("value violates a constraint", nil).Wrap(err)
+		case pgQueryCanceled, pgTooManyConnections, pgSerializationFailure, pgDeadlockDetected:
+			return Unavailable("database busy, retry later").Wrap(err)
+		}
+		// connection exceptions, class 08
+		if len(pgErr.Code) == 5 && pgErr.Code[:2] == "08" {
+			return Unavailable("database unavailable").Wrap(err)
+		}
+	}
+
+	var connErr *pgconn.ConnectError
+	if errors.As(err, &connErr) {
+		return Unavailable("database unavailable").Wrap(err)
+	}
+
+	return Internal("internal server error").Wrap(err)
+}
@@ -5,15 +5,21 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	validationutils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/validation_utils"
 	"github.com/i-sub135/go-rest-blueprint/source/config"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
 )
 
 type response struct {
 	Status     string    `json:"status"`
+	Code       string    `json:"code,omitempty"`
 	Message    *string   `json:"message,omitempty"`
 	Time       time.Time `json:"timestamp"`
 	AppVersion string    `json:"app_version"`
 	Data       any       `json:"data,omitempty"`
+	Meta       any       `json:"meta,omitempty"`
+	Errors     any       `json:"errors,omitempty"`
 }
 
 var (
@@ -29,29 +35,90 @@ func HttpRespOK(c *gin.Context, data any, msg *string) {
 		Message:    msg,
 	})
 }
-func HttpRespNotFound(c *gin.Context, msg *string) {
-	c.JSON(http.StatusNotFound, response{
-		Status:     http.StatusText(http.StatusNotFound),
-		Message:    msg,
-		AppVersion: cfg.App.Version,
+
+// HttpRespOKWithMeta is HttpRespOK plus a meta object, e.g. paginate.Meta for list endpoints.
+func HttpRespOKWithMeta(c *gin.Context, data any, meta any) {
+	c.JSON(http.StatusOK, response{
+		Status:     http.StatusText(http.StatusOK),
 		Time:       time.Now(),
+		AppVersion: cfg.App.Version,
+		Data:       data,
+		Meta:       meta,
 	})
 }
 
-func HttpRespBadRequest(c *gin.Context, msg *string) {
-	c.JSON(http.StatusBadRequest, response{
-		Status:     http.StatusText(http.StatusBadRequest),
-		Message:    msg,
-		AppVersion: cfg.App.Version,
+func HttpRespCreated(c *gin.Context, data any, msg *string) {
+	c.JSON(http.StatusCreated, response{
+		Status:     http.StatusText(http.StatusCreated),
 		Time:       time.Now(),
+		AppVersion: cfg.App.Version,
+		Data:       data,
+		Message:    msg,
 	})
 }
 
-func HttpRespBadGateway(c *gin.Context, msg *string) {
-	c.JSON(http.StatusBadGateway, response{
-		Status:     http.StatusText(http.StatusBadGateway),
+func HttpRespUnsupportedMediaType(c *gin.Context, msg *string) {
+	writeError(c, http.StatusUnsupportedMediaType, "", msg, nil)
+}
+
+// HttpRespError answers with the status, code and safe message of err mapped
+// through apperror.From. The full cause is logged with the request_id, never sent.
+func HttpRespError(c *gin.Context, err error) {
+	respondError(c, err)
+}
+
+// respondError is shared by the exported helpers so the logged caller is
+// always the handler, one frame above them.
+func respondError(c *gin.Context, err error) {
+	ae := apperror.From(err)
+	status := ae.Kind.HTTPStatus()
+
+	ev := logger.Warn(c.Request.Context())
+	if status >= http.StatusInternalServerError {
+		ev = logger.Error(c.Request.Context())
+	}
+	ev.Caller(1).
+		Err(err).
+		Str("code", ae.Code).
+		Int("status", status).
+		Msg(ae.Message)
+
+	var fields any
+	if len(ae.Fields) > 0 {
+		fields = ae.Fields
+	}
+	writeError(c, status, ae.Code, &ae.Message, fields)
+}
+
+// writeError aborts with either the envelope or an RFC 7807 problem document,
+// see wantsProblem.
+func writeError(c *gin.Context, status int, code string, msg *string, fields any) {
+	if wantsProblem(c) {
+		var detail string
+		if msg != nil {
+			detail = *msg
+		}
+		c.Header("Content-Type", MIMEProblemJSON)
+		c.AbortWithStatusJSON(status, newProblem(c, status, code, detail, fields))
+		return
+	}
+
+	c.AbortWithStatusJSON(status, response{
+		Status:     apperror.StatusText(status),
+		Code:       code,
 		Message:    msg,
 		AppVersion: cfg.App.Version,
 		Time:       time.Now(),
+		Errors:     fields,
 	})
 }
+
+// HttpRespBindError answers a failed ShouldBind*: 422 with field errors for
+// validation failures, 400 for anything else (malformed JSON, wrong types).
+func HttpRespBindError(c *gin.Context, err error) {
+	if _, ok := validationutils.FieldErrors(err); ok {
+		respondError(c, err)
+		return
+	}
+	respondError(c, apperror.BadRequest("invalid request body").Wrap(err))
+}
@@ -0,0 +1,56 @@
+package httpresputils
+
+import (
+	"mime"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	"github.com/i-sub135/go-rest-blueprint/source/service/constant"
+)
+
+const MIMEProblemJSON = "application/problem+json"
+
+// problem is an RFC 7807 problem details document. Code, RequestID and
+// Errors are extension members.
+type problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	Errors    any    `json:"errors,omitempty"`
+}
+
+// wantsProblem reports whether the error body should be a problem document:
+// http.error_format is "problem" or the client accepts application/problem+json.
+func wantsProblem(c *gin.Context) bool {
+	if cfg.HTTP.ErrorFormat == "problem" {
+		return true
+	}
+	for _, part := range strings.Split(c.GetHeader("Accept"), ",") {
+		if mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part)); err == nil && mediaType == MIMEProblemJSON {
+			return true
+		}
+	}
+	return false
+}
+
+func newProblem(c *gin.Context, status int, code, detail string, fields any) problem {
+	typ := "about:blank"
+	if cfg.HTTP.ProblemTypeBase != "" && code != "" {
+		typ = strings.TrimSuffix(cfg.HTTP.ProblemTypeBase, "/") + "/" + code
+	}
+	return problem{
+		Type:      typ,
+		Title:     apperror.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		Code:      code,
+		RequestID: c.GetString(constant.RequestIDKey),
+		Errors:    fields,
+	}
+}
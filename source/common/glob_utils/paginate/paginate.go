@@ -0,0 +1,219 @@
+package paginate
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Params describes one page request. Cursor mode is used when Cursor is set,
+// otherwise Page selects an offset page.
+type Params struct {
+	Page     int
+	PageSize int
+	Cursor   string
+	Sort     string // whitelisted column name
+	Desc     bool
+}
+
+// Meta is the page metadata returned next to the data in the response envelope.
+type Meta struct {
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size"`
+	Total      int64  `json:"total"`
+	TotalPages int64  `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// cursor is the decoded form of the opaque cursor string. Sort and Desc are
+// carried so a cursor cannot be replayed against a different ordering.
+type cursor struct {
+	Value    any    `json:"v"`
+	ID       uint   `json:"id"`
+	Backward bool   `json:"b,omitempty"`
+	Sort     string `json:"s"`
+	Desc     bool   `json:"d,omitempty"`
+}
+
+func encodeCursor(c cursor) string {
+	if t, ok := c.Value.(time.Time); ok {
+		c.Value = t.Format(time.RFC3339Nano)
+	}
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return c, ErrInvalidCursor
+	}
+
+	// the value is bound next to the sort column, so it must have its type:
+	// a number for id, RFC3339 for timestamps (named *_at), text otherwise
+	switch v := c.Value.(type) {
+	case float64:
+		if c.Sort != "id" {
+			return c, ErrInvalidCursor
+		}
+	case string:
+		if c.Sort == "id" {
+			return c, ErrInvalidCursor
+		}
+		if strings.HasSuffix(c.Sort, "_at") {
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return c, ErrInvalidCursor
+			}
+			c.Value = t
+		}
+	default:
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// FromQuery reads page, page_size, cursor, sort and order from the query
+// string. sort must be one of allowedSort; defaultSort is used when empty.
+func FromQuery(c *gin.Context, allowedSort []string, defaultSort string) (Params, error) {
+	p := Params{
+		Page:     1,
+		PageSize: DefaultPageSize,
+		Cursor:   c.Query("cursor"),
+		Sort:     c.DefaultQuery("sort", defaultSort),
+	}
+
+	if v := c.Query("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			return p, fmt.Errorf("page must be a positive integer")
+		}
+		p.Page = page
+	}
+	if v := c.Query("page_size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size < 1 {
+			return p, fmt.Errorf("page_size must be a positive integer")
+		}
+		p.PageSize = min(size, MaxPageSize)
+	}
+
+	if !slices.Contains(allowedSort, p.Sort) {
+		return p, fmt.Errorf("sort must be one of %v", allowedSort)
+	}
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		p.Desc = true
+	default:
+		return p, fmt.Errorf("order must be asc or desc")
+	}
+
+	if p.Cursor != "" {
+		cur, err := decodeCursor(p.Cursor)
+		if err != nil {
+			return p, err
+		}
+		if cur.Sort != p.Sort || cur.Desc != p.Desc {
+			return p, fmt.Errorf("cursor was issued for a different sort order")
+		}
+	}
+	return p, nil
+}
+
+// Find runs q (already filtered and bound to a model) for one page. key
+// returns the sort column value and primary key of a row, used to build the
+// next/prev cursors. Rows are ordered by (Sort, id) so ties stay stable.
+func Find[T any](q *gorm.DB, p Params, key func(*T) (any, uint)) ([]T, *Meta, error) {
+	meta := &Meta{PageSize: p.PageSize}
+
+	if err := q.Session(&gorm.Session{}).Count(&meta.Total).Error; err != nil {
+		return nil, nil, err
+	}
+	meta.TotalPages = (meta.Total + int64(p.PageSize) - 1) / int64(p.PageSize)
+
+	var (
+		cur      cursor
+		backward bool
+	)
+	if p.Cursor != "" {
+		var err error
+		if cur, err = decodeCursor(p.Cursor); err != nil {
+			return nil, nil, err
+		}
+		backward = cur.Backward
+	}
+
+	// walking backward reverses the order, rows are flipped back after the fetch
+	desc := p.Desc != backward
+	dir := "ASC"
+	if desc {
+		dir = "DESC"
+	}
+
+	page := q.Session(&gorm.Session{}).
+		Order(fmt.Sprintf("%s %s, id %s", p.Sort, dir, dir)).
+		Limit(p.PageSize + 1)
+
+	if p.Cursor != "" {
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		page = page.Where(fmt.Sprintf("(%s, id) %s (?, ?)", p.Sort, op), cur.Value, cur.ID)
+	} else {
+		meta.Page = p.Page
+		page = page.Offset((p.Page - 1) * p.PageSize)
+	}
+
+	var rows []T
+	if err := page.Find(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+
+	hasMore := len(rows) > p.PageSize
+	if hasMore {
+		rows = rows[:p.PageSize]
+	}
+	if backward {
+		slices.Reverse(rows)
+	}
+	if len(rows) == 0 {
+		return rows, meta, nil
+	}
+
+	// a next page exists when we fetched an extra row going forward, or we came back from one;
+	// a previous page exists when we fetched an extra row going backward, or moved past page 1
+	hasNext := (!backward && hasMore) || backward
+	hasPrev := (backward && hasMore) || (!backward && (p.Cursor != "" || p.Page > 1))
+
+	if hasNext {
+		v, id := key(&rows[len(rows)-1])
+		meta.NextCursor = encodeCursor(cursor{Value: v, ID: id, Sort: p.Sort, Desc: p.Desc})
+	}
+	if hasPrev {
+		v, id := key(&rows[0])
+		meta.PrevCursor = encodeCursor(cursor{Value: v, ID: id, Backward: true, Sort: p.Sort, Desc: p.Desc})
+	}
+	return rows, meta, nil
+}
@@ -7,16 +7,16 @@ import (
 )
 
 type Customer struct {
-	ID          uint           `gorm:"primaryKey" json:"-"`
+	ID          uint           `gorm:"primaryKey" json:"id"`
 	FirstName   string         `gorm:"not null;size:100" json:"first_name"`
 	LastName    string         `gorm:"not null;size:100" json:"last_name"`
-	Email       string         `gorm:"unique;not null;size:255" json:"email"`
-	Phone       string         `gorm:"size:20" json:"phone,omitempty"`
-	Address     string         `gorm:"type:text" json:"address,omitempty"`
+	Email       string         `gorm:"unique;not null;size:255" json:"email" redact:"pii"`
+	Phone       string         `gorm:"size:20" json:"phone,omitempty" redact:"pii"`
+	Address     string         `gorm:"type:text" json:"address,omitempty" redact:"pii"`
 	City        string         `gorm:"size:100" json:"city,omitempty"`
 	Country     string         `gorm:"size:100;default:'Indonesia'" json:"country"`
-	DateOfBirth *time.Time     `gorm:"type:date" json:"date_of_birth,omitempty"`
-	IsActive    bool           `gorm:"default:true" json:"is_active"`
+	DateOfBirth *time.Time     `gorm:"type:date" json:"date_of_birth,omitempty" redact:"pii"`
+	IsActive    bool           `json:"is_active"` // set on every insert, the column default only covers raw SQL
 	CreatedAt   time.Time      `json:"-"`
 	UpdatedAt   time.Time      `json:"-"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
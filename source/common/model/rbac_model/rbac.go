@@ -0,0 +1,43 @@
+package rbacmodel
+
+import "time"
+
+// Role groups permissions; users get roles through user_roles.
+type Role struct {
+	ID          uint         `gorm:"primaryKey" json:"id"`
+	Name        string       `gorm:"unique;not null;size:100" json:"name"`
+	Description string       `gorm:"type:text" json:"description,omitempty"`
+	Permissions []Permission `gorm:"many2many:role_permissions" json:"permissions,omitempty"`
+	CreatedAt   time.Time    `json:"-"`
+	UpdatedAt   time.Time    `json:"-"`
+}
+
+// TableName returns the table name for Role model
+func (Role) TableName() string {
+	return "roles"
+}
+
+// Permission is a "resource:action[:qualifier]" name such as users:read:any.
+type Permission struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"unique;not null;size:100" json:"name"`
+	Description string    `gorm:"type:text" json:"description,omitempty"`
+	CreatedAt   time.Time `json:"-"`
+}
+
+// TableName returns the table name for Permission model
+func (Permission) TableName() string {
+	return "permissions"
+}
+
+// UserRole links a usermodel.User to a Role.
+type UserRole struct {
+	UserID    uint      `gorm:"primaryKey" json:"user_id"`
+	RoleID    uint      `gorm:"primaryKey" json:"role_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for UserRole model
+func (UserRole) TableName() string {
+	return "user_roles"
+}
@@ -7,9 +7,9 @@ import (
 )
 
 type User struct {
-	ID        uint           `gorm:"primaryKey" json:"-"`
+	ID        uint           `gorm:"primaryKey" json:"id"`
 	Name      string         `gorm:"not null" json:"name"`
-	Email     string         `gorm:"unique;not null" json:"email"`
+	Email     string         `gorm:"unique;not null" json:"email" redact:"pii"`
 	CreatedAt time.Time      `json:"-"`
 	UpdatedAt time.Time      `json:"-"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
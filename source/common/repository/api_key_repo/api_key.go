@@ -0,0 +1,103 @@
+package apikeyrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	"github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/paginate"
+	apikeymodel "github.com/i-sub135/go-rest-blueprint/source/common/model/api_key_model"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/metrics"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepo struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) *APIKeyRepo {
+	return &APIKeyRepo{db: metrics.Repository(db, "api_key")}
+}
+
+// Domain errors returned in place of the raw gorm ones, see translate.
+var ErrAPIKeyNotFound = apperror.NotFound("api key not found").WithCode("api_key_not_found")
+
+func translate(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrAPIKeyNotFound.Wrap(err)
+	}
+	return err
+}
+
+// ListFilter narrows List results, zero values are ignored.
+type ListFilter struct {
+	Owner          string
+	IncludeRevoked bool
+}
+
+// ListSortFields are the columns List accepts in paginate.Params.Sort.
+var ListSortFields = []string{"name", "created_at"}
+
+func (r *APIKeyRepo) List(ctx context.Context, filter ListFilter, page paginate.Params) (*[]apikeymodel.APIKey, *paginate.Meta, error) {
+	q := r.db.WithContext(ctx).Model(&apikeymodel.APIKey{})
+	if filter.Owner != "" {
+		q = q.Where("owner = ?", filter.Owner)
+	}
+	if !filter.IncludeRevoked {
+		q = q.Where("revoked_at IS NULL")
+	}
+
+	keys, meta, err := paginate.Find(q, page, func(k *apikeymodel.APIKey) (any, uint) {
+		if page.Sort == "name" {
+			return k.Name, k.ID
+		}
+		return k.CreatedAt, k.ID
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &keys, meta, nil
+}
+
+func (r *APIKeyRepo) GetByID(ctx context.Context, id uint) (*apikeymodel.APIKey, error) {
+	var key apikeymodel.APIKey
+	if err := r.db.WithContext(ctx).First(&key, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &key, nil
+}
+
+// GetByHash finds a key by the SHA-256 hash of its secret, see auth.HashAPIKey.
+func (r *APIKeyRepo) GetByHash(ctx context.Context, hash string) (*apikeymodel.APIKey, error) {
+	var key apikeymodel.APIKey
+	if err := r.db.WithContext(ctx).Where("key_hash = ?", hash).First(&key).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, key *apikeymodel.APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+// Rotate replaces the secret of a key in place, the old secret stops working.
+func (r *APIKeyRepo) Rotate(ctx context.Context, key *apikeymodel.APIKey, prefix, hash string) error {
+	err := r.db.WithContext(ctx).Model(key).Updates(map[string]any{
+		"prefix":   prefix,
+		"key_hash": hash,
+	}).Error
+	return translate(err)
+}
+
+func (r *APIKeyRepo) Revoke(ctx context.Context, key *apikeymodel.APIKey, at time.Time) error {
+	return translate(r.db.WithContext(ctx).Model(key).Update("revoked_at", at).Error)
+}
+
+// TouchLastUsed records a use without bumping updated_at.
+func (r *APIKeyRepo) TouchLastUsed(ctx context.Context, id uint, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&apikeymodel.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", at).Error
+}
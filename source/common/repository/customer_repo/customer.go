@@ -2,8 +2,14 @@ package customerrepo
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	globutils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/paginate"
 	customermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/customer_model"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/metrics"
 	"gorm.io/gorm"
 )
 
@@ -12,7 +18,23 @@ type CustomerRepo struct {
 }
 
 func NewRepo(db *gorm.DB) *CustomerRepo {
-	return &CustomerRepo{db: db}
+	return &CustomerRepo{db: metrics.Repository(db, "customer")}
+}
+
+// Domain errors returned in place of the raw gorm ones, see translate.
+var (
+	ErrCustomerNotFound = apperror.NotFound("customer not found").WithCode("customer_not_found")
+	ErrEmailTaken       = apperror.Conflict("email already registered").WithCode("email_taken")
+)
+
+func translate(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrCustomerNotFound.Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrEmailTaken.Wrap(err)
+	}
+	return err
 }
 
 func (cs *CustomerRepo) GetCustomerFirstName(ctx context.Context, name string) (*[]customermodel.Customer, error) {
@@ -26,3 +48,100 @@ func (cs *CustomerRepo) GetCustomerFirstName(ctx context.Context, name string) (
 	}
 	return &customers, nil
 }
+
+// ListFilter narrows List results, nil/zero values are ignored.
+type ListFilter struct {
+	City     string
+	Country  string
+	IsActive *bool
+	MinAge   *int
+	MaxAge   *int
+}
+
+// ListSortFields are the columns List accepts in paginate.Params.Sort.
+var ListSortFields = []string{"first_name", "last_name", "email", "created_at"}
+
+func (cs *CustomerRepo) List(ctx context.Context, filter ListFilter, page paginate.Params) (*[]customermodel.Customer, *paginate.Meta, error) {
+	q := cs.db.WithContext(ctx).Model(&customermodel.Customer{})
+	if filter.City != "" {
+		q = q.Where("city ILIKE ?", globutils.EscapeLike(filter.City))
+	}
+	if filter.Country != "" {
+		q = q.Where("country ILIKE ?", globutils.EscapeLike(filter.Country))
+	}
+	if filter.IsActive != nil {
+		q = q.Where("is_active = ?", *filter.IsActive)
+	}
+
+	// age bounds translate to date_of_birth bounds relative to today
+	today := time.Now().Truncate(24 * time.Hour)
+	if filter.MinAge != nil {
+		q = q.Where("date_of_birth <= ?", today.AddDate(-*filter.MinAge, 0, 0))
+	}
+	if filter.MaxAge != nil {
+		q = q.Where("date_of_birth > ?", today.AddDate(-*filter.MaxAge-1, 0, 0))
+	}
+
+	customers, meta, err := paginate.Find(q, page, func(c *customermodel.Customer) (any, uint) {
+		switch page.Sort {
+		case "first_name":
+			return c.FirstName, c.ID
+		case "last_name":
+			return c.LastName, c.ID
+		case "email":
+			return c.Email, c.ID
+		default:
+			return c.CreatedAt, c.ID
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &customers, meta, nil
+}
+
+func (cs *CustomerRepo) GetByID(ctx context.Context, id uint) (*customermodel.Customer, error) {
+	var customer customermodel.Customer
+	err := cs.db.WithContext(ctx).First(&customer, id).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+	return &customer, nil
+}
+
+// GetByIDWithDeleted also finds soft-deleted customers.
+func (cs *CustomerRepo) GetByIDWithDeleted(ctx context.Context, id uint) (*customermodel.Customer, error) {
+	var customer customermodel.Customer
+	err := cs.db.WithContext(ctx).Unscoped().First(&customer, id).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+	return &customer, nil
+}
+
+func (cs *CustomerRepo) Create(ctx context.Context, customer *customermodel.Customer) error {
+	return translate(cs.db.WithContext(ctx).Create(customer).Error)
+}
+
+func (cs *CustomerRepo) Update(ctx context.Context, customer *customermodel.Customer) error {
+	return translate(cs.db.WithContext(ctx).Save(customer).Error)
+}
+
+// Delete soft-deletes the customer, Restore undoes it.
+func (cs *CustomerRepo) Delete(ctx context.Context, id uint) error {
+	return translate(cs.db.WithContext(ctx).Delete(&customermodel.Customer{}, id).Error)
+}
+
+func (cs *CustomerRepo) Restore(ctx context.Context, id uint) error {
+	return translate(cs.db.WithContext(ctx).Unscoped().
+		Model(&customermodel.Customer{}).
+		Where("id = ?", id).
+		Update("deleted_at", nil).Error)
+}
+
+func (cs *CustomerRepo) SetActive(ctx context.Context, id uint, active bool) error {
+	return translate(cs.db.WithContext(ctx).
+		Model(&customermodel.Customer{}).
+		Where("id = ?", id).
+		Update("is_active", active).Error)
+}
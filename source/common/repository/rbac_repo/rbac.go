@@ -0,0 +1,34 @@
+package rbacrepo
+
+import (
+	"context"
+
+	rbacmodel "github.com/i-sub135/go-rest-blueprint/source/common/model/rbac_model"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/metrics"
+	"gorm.io/gorm"
+)
+
+type RBACRepo struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) *RBACRepo {
+	return &RBACRepo{db: metrics.Repository(db, "rbac")}
+}
+
+// PermissionsOfUser returns the distinct permission names granted to a user
+// through all of their roles.
+func (r *RBACRepo) PermissionsOfUser(ctx context.Context, userID uint) ([]string, error) {
+	var names []string
+	err := r.db.WithContext(ctx).
+		Model(&rbacmodel.Permission{}).
+		Distinct("permissions.name").
+		Joins("JOIN role_permissions rp ON rp.permission_id = permissions.id").
+		Joins("JOIN user_roles ur ON ur.role_id = rp.role_id").
+		Where("ur.user_id = ?", userID).
+		Pluck("permissions.name", &names).Error
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
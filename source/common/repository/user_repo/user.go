@@ -2,8 +2,14 @@ package userrepo
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	globutils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/paginate"
 	usermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/user_model"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/metrics"
 	"gorm.io/gorm"
 )
 
@@ -12,23 +18,72 @@ type UserRepo struct {
 }
 
 func NewUserRepo(db *gorm.DB) *UserRepo {
-	return &UserRepo{DB: db}
+	return &UserRepo{DB: metrics.Repository(db, "user")}
 }
 
-func (r *UserRepo) GetAll(ctx context.Context) (*[]usermodel.User, error) {
-	var user []usermodel.User
-	err := r.DB.WithContext(ctx).Find(&user).Error
+// Domain errors returned in place of the raw gorm ones, see translate.
+var (
+	ErrUserNotFound = apperror.NotFound("user not found").WithCode("user_not_found")
+	ErrEmailTaken   = apperror.Conflict("email already registered").WithCode("email_taken")
+)
+
+func translate(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrUserNotFound.Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrEmailTaken.Wrap(err)
+	}
+	return err
+}
+
+// ListFilter narrows List results, zero values are ignored.
+type ListFilter struct {
+	NameContains string
+	EmailDomain  string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+}
+
+// ListSortFields are the columns List accepts in paginate.Params.Sort.
+var ListSortFields = []string{"name", "email", "created_at"}
+
+func (r *UserRepo) List(ctx context.Context, filter ListFilter, page paginate.Params) (*[]usermodel.User, *paginate.Meta, error) {
+	q := r.DB.WithContext(ctx).Model(&usermodel.User{})
+	if filter.NameContains != "" {
+		q = q.Where("name ILIKE ?", "%"+globutils.EscapeLike(filter.NameContains)+"%")
+	}
+	if filter.EmailDomain != "" {
+		q = q.Where("email ILIKE ?", "%@"+globutils.EscapeLike(filter.EmailDomain))
+	}
+	if filter.CreatedFrom != nil {
+		q = q.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		q = q.Where("created_at <= ?", *filter.CreatedTo)
+	}
+
+	users, meta, err := paginate.Find(q, page, func(u *usermodel.User) (any, uint) {
+		switch page.Sort {
+		case "name":
+			return u.Name, u.ID
+		case "email":
+			return u.Email, u.ID
+		default:
+			return u.CreatedAt, u.ID
+		}
+	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return &user, nil
+	return &users, meta, nil
 }
 
 func (r *UserRepo) GetByID(ctx context.Context, id uint) (*usermodel.User, error) {
 	var user usermodel.User
 	err := r.DB.WithContext(ctx).First(&user, id).Error
 	if err != nil {
-		return nil, err
+		return nil, translate(err)
 	}
 	return &user, nil
 }
@@ -37,19 +92,19 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*usermodel.Use
 	var user usermodel.User
 	err := r.DB.WithContext(ctx).Where("email = ?", email).First(&user).Error
 	if err != nil {
-		return nil, err
+		return nil, translate(err)
 	}
 	return &user, nil
 }
 
 func (r *UserRepo) Create(ctx context.Context, user *usermodel.User) error {
-	return r.DB.WithContext(ctx).Create(user).Error
+	return translate(r.DB.WithContext(ctx).Create(user).Error)
 }
 
 func (r *UserRepo) Update(ctx context.Context, user *usermodel.User) error {
-	return r.DB.WithContext(ctx).Save(user).Error
+	return translate(r.DB.WithContext(ctx).Save(user).Error)
 }
 
 func (r *UserRepo) Delete(ctx context.Context, id uint) error {
-	return r.DB.WithContext(ctx).Delete(&usermodel.User{}, id).Error
+	return translate(r.DB.WithContext(ctx).Delete(&usermodel.User{}, id).Error)
 }
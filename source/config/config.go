@@ -1,8 +1,15 @@
 package config
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
 	"log"
 	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/knadh/koanf/parsers/yaml"
@@ -23,52 +30,268 @@ func readVersionFile() string {
 var k = koanf.New(".")
 var cfg Config
 
-// LoadConfig loads config from a YAML file (optional) and env overrides.
+// Strict rejects config file keys that Config does not declare, so a typo
+// fails at startup instead of silently leaving the default in place.
+var Strict = true
+
+// Overrides are set by the --set flag and applied over every other layer.
+var Overrides = map[string]string{}
+
+// OverrideLayer names the untracked overlay read after the profile one,
+// config.override.yaml for config.yaml. app.env cannot take this name.
+const OverrideLayer = "override"
+
+// LoadConfig loads the config layers into the Config returned by GetConfig.
 // Call once at bootstrap.
+//
+// Layers, each overriding the ones before:
+//  1. path, the base file (optional)
+//  2. config.<app.env>.yaml next to it, app.env taken from --set, APP_ENV or
+//     the base file, default local
+//  3. config.override.yaml next to it, untracked machine-local overrides
+//     applied in every environment, so override cannot be a profile name
+//  4. env variables, APP_PORT -> app.port
+//  5. --set key=value flags, see Overrides
+//
+// Missing files are skipped; see Config.Source for where a value came from.
 func LoadConfig(path string) error {
-	// try load file if present
+	return load(k, path, &cfg)
+}
+
+// Load reads the config again into a new Config, leaving the one returned by
+// GetConfig untouched, e.g. to pick up log levels on SIGHUP.
+func Load(path string) (*Config, error) {
+	var out Config
+	if err := load(koanf.New("."), path, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func load(k *koanf.Koanf, path string, out *Config) error {
+	sources := map[string]string{}
+	var errs []error
+
+	// merge adds a layer, recording it as the source of each of its keys
+	merge := func(layer *koanf.Koanf, source string) {
+		if Strict {
+			for _, key := range unknownKeys(layer.Raw(), configType, "") {
+				errs = append(errs, unknownKeyError(key, source))
+			}
+		}
+		for _, key := range layer.Keys() {
+			sources[key] = source
+		}
+		k.Merge(layer)
+	}
+
+	flags := koanf.New(".")
+	for key, value := range Overrides {
+		flags.Set(key, value)
+	}
+
 	if path != "" {
-		if err := k.Load(file.Provider(path), yaml.Parser()); err != nil {
-			log.Printf("warning: config file not loaded: %v", err)
-			// continue - env can still provide values
+		base, err := readFile(path)
+		if err != nil {
+			return err
+		}
+		if base == nil {
+			// env and defaults can still provide every value
+			log.Printf("warning: config file %s not found", path)
+			base = koanf.New(".")
+		}
+		merge(base, "file "+path)
+
+		envName := cmp.Or(flags.String("app.env"), os.Getenv("APP_ENV"), base.String("app.env"), "local")
+		for _, name := range slices.Compact([]string{envName, OverrideLayer}) {
+			overlay := layerPath(path, name)
+			layer, err := readFile(overlay)
+			if err != nil {
+				return err
+			}
+			if layer != nil {
+				merge(layer, "file "+overlay)
+			}
 		}
 	}
 
-	// env provider: convert APP_ENV -> app.env
-	if err := k.Load(env.Provider("", ".", func(s string) string {
-		return strings.ToLower(strings.ReplaceAll(s, "_", "."))
+	// env provider: APP_PORT -> app.port, DB_MAX_OPEN_CONNS -> db.max_open_conns;
+	// variables naming no key are ignored
+	names := newEnvNames()
+	if err := k.Load(env.ProviderWithValue("", ".", func(name, value string) (string, any) {
+		key := names.key(name)
+		if key != "" {
+			sources[key] = "env " + name
+		}
+		return key, value
 	}), nil); err != nil {
 		return err
 	}
 
+	merge(flags, "flag --set")
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	setDefault := func(key string, value any) {
+		k.Set(key, value)
+		sources[key] = "default"
+	}
+
 	// set defaults if not provided
 	if k.String("app.name") == "" {
-		k.Set("app.name", "github.com/i-sub135/go-rest-blueprint")
+		setDefault("app.name", "github.com/i-sub135/go-rest-blueprint")
 	}
 	if k.String("app.env") == "" {
-		k.Set("app.env", "local")
+		setDefault("app.env", "local")
 	}
 	if k.Int("app.port") == 0 {
-		k.Set("app.port", 8080)
+		setDefault("app.port", 8080)
 	}
 	// Read version from file and set if not provided via config
 	if k.String("app.version") == "" {
 		version := readVersionFile()
-		k.Set("app.version", version)
+		setDefault("app.version", version)
 	}
 	if k.String("log.level") == "" {
-		k.Set("log.level", "debug")
+		setDefault("log.level", "debug")
+	}
+	if !k.Exists("log.sampling.burst") {
+		setDefault("log.sampling.burst", 100)
+	}
+	if !k.Exists("log.sampling.period") {
+		setDefault("log.sampling.period", "1s")
+	}
+	if !k.Exists("log.sampling.thereafter") {
+		setDefault("log.sampling.thereafter", 100)
+	}
+	if k.String("log.sampling.max_level") == "" {
+		setDefault("log.sampling.max_level", "warn")
+	}
+	if !k.Exists("log.redact.enabled") {
+		setDefault("log.redact.enabled", true)
+	}
+	if !k.Exists("log.slow_query") {
+		setDefault("log.slow_query", "200ms")
+	}
+	if !k.Exists("log.redact_sql") {
+		setDefault("log.redact_sql", true)
+	}
+	if !k.Exists("http.shutdown_timeout") {
+		setDefault("http.shutdown_timeout", "15s")
+	}
+	if !k.Exists("http.drain_delay") {
+		setDefault("http.drain_delay", "5s")
+	}
+	if k.String("http.error_format") == "" {
+		setDefault("http.error_format", "envelope")
+	}
+	if !k.Exists("http.security.cors.allowed_methods") {
+		setDefault("http.security.cors.allowed_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE"})
+	}
+	if !k.Exists("http.security.cors.allowed_headers") {
+		setDefault("http.security.cors.allowed_headers", []string{"Authorization", "Content-Type", "X-API-Key", "X-Request-ID", "X-Read-Your-Writes"})
+	}
+	if !k.Exists("http.security.cors.exposed_headers") {
+		setDefault("http.security.cors.exposed_headers", []string{"X-Request-ID", "Location", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"})
+	}
+	if !k.Exists("http.security.cors.max_age") {
+		setDefault("http.security.cors.max_age", "10m")
+	}
+	if !k.Exists("http.security.content_type_nosniff") {
+		setDefault("http.security.content_type_nosniff", true)
+	}
+	if !k.Exists("http.security.frame_options") {
+		setDefault("http.security.frame_options", "DENY")
+	}
+	if !k.Exists("http.security.content_security_policy") {
+		setDefault("http.security.content_security_policy", "default-src 'none'; frame-ancestors 'none'")
+	}
+	if !k.Exists("auth.jwt.leeway") {
+		setDefault("auth.jwt.leeway", "30s")
+	}
+	if !k.Exists("auth.api_key.cache_ttl") {
+		setDefault("auth.api_key.cache_ttl", "1m")
+	}
+	if !k.Exists("auth.rbac.cache_ttl") {
+		setDefault("auth.rbac.cache_ttl", "1m")
+	}
+	if !k.Exists("admin.enabled") {
+		setDefault("admin.enabled", true)
+	}
+	if !k.Exists("health.timeout") {
+		setDefault("health.timeout", "5s")
+	}
+	if !k.Exists("health.cache_ttl") {
+		setDefault("health.cache_ttl", "1s")
+	}
+	if k.Int("admin.port") == 0 {
+		setDefault("admin.port", 9090)
+	}
+	if k.String("tracing.exporter") == "" {
+		setDefault("tracing.exporter", "otlp")
+	}
+	if !k.Exists("tracing.sample_ratio") {
+		setDefault("tracing.sample_ratio", 1.0)
 	}
 	if k.String("db.dsn") == "" {
-		k.Set("db.dsn", "host=localhost user=postgres password=postgres dbname=myapp port=5432 sslmode=disable TimeZone=Asia/Jakarta")
+		setDefault("db.dsn", "host=localhost user=postgres password=postgres dbname=myapp port=5432 sslmode=disable TimeZone=Asia/Jakarta")
+	}
+	if !k.Exists("db.max_open_conns") {
+		setDefault("db.max_open_conns", 25)
+	}
+	if !k.Exists("db.max_idle_conns") {
+		setDefault("db.max_idle_conns", 10)
+	}
+	if !k.Exists("db.conn_max_lifetime") {
+		setDefault("db.conn_max_lifetime", "30m")
+	}
+	if !k.Exists("db.conn_max_idle_time") {
+		setDefault("db.conn_max_idle_time", "5m")
+	}
+	if !k.Exists("db.connect_timeout") {
+		setDefault("db.connect_timeout", "10s")
+	}
+	if !k.Exists("db.replica_check_interval") {
+		setDefault("db.replica_check_interval", "10s")
+	}
+	if k.String("db.application_name") == "" {
+		setDefault("db.application_name", k.String("app.name"))
 	}
 
-	if err := k.Unmarshal("", &cfg); err != nil {
+	if err := k.Unmarshal("", out); err != nil {
 		return err
 	}
+	out.sources = sources
 	return nil
 }
 
+// readFile parses the YAML file at path, nil when there is none.
+func readFile(path string) (*koanf.Koanf, error) {
+	fk := koanf.New(".")
+	if err := fk.Load(file.Provider(path), yaml.Parser()); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+	return fk, nil
+}
+
+// layerPath returns the overlay of base named name: config.yaml and
+// production give config.production.yaml.
+func layerPath(base, name string) string {
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "." + name + ext
+}
+
+func unknownKeyError(key, source string) error {
+	if s := suggest(key); s != "" {
+		return fmt.Errorf("%s (%s): unknown key, did you mean %s?", key, source, s)
+	}
+	return fmt.Errorf("%s (%s): unknown key", key, source)
+}
+
 func GetConfig() *Config  { return &cfg }
 func Koanf() *koanf.Koanf { return k }
 
@@ -77,3 +300,57 @@ func ResetConfig() {
 	k = koanf.New(".")
 	cfg = Config{}
 }
+
+// sections returns the top-level keys declared on Config.
+func sections() []string {
+	t := reflect.TypeOf(Config{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		keys = append(keys, t.Field(i).Tag.Get("koanf"))
+	}
+	return keys
+}
+
+// Effective returns the loaded values limited to the sections declared on
+// Config, leaving out unrelated environment variables picked up by the env provider.
+func Effective() *koanf.Koanf {
+	out := koanf.New(".")
+	for _, s := range sections() {
+		if k.Exists(s) {
+			out.MergeAt(k.Cut(s), s)
+		}
+	}
+	return out
+}
+
+// Source tells where the value of key came from: "file <path>",
+// "env <NAME>" or "default". A key holding a section, e.g. auth.jwt, reports
+// the first of its keys set by a file, env or flag. A key inside a list or
+// map without a source of its own reports that of the enclosing value.
+func (c *Config) Source(key string) string {
+	if s, ok := c.sources[key]; ok {
+		return s
+	}
+	var children []string
+	for k := range c.sources {
+		if strings.HasPrefix(k, key+".") || strings.HasPrefix(k, key+"[") {
+			children = append(children, k)
+		}
+	}
+	slices.Sort(children)
+	for _, child := range children {
+		if s := c.sources[child]; s != "default" {
+			return s
+		}
+	}
+	for {
+		i := strings.LastIndexAny(key, ".[")
+		if i < 0 {
+			return "default"
+		}
+		key = key[:i]
+		if s, ok := c.sources[key]; ok {
+			return s
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The key schema is read from the koanf tags of Config, so env variable
+// names and unknown key checks follow the struct without a separate list.
+
+var (
+	configType = reflect.TypeFor[Config]()
+	listIndex  = regexp.MustCompile(`\[\d+\]`)
+)
+
+// walkKeys calls fn for every key under t that holds a value, stopping at
+// maps and slices.
+func walkKeys(t reflect.Type, prefix string, fn func(key string, t reflect.Type)) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("koanf")
+		if tag == "" {
+			continue
+		}
+		key := joinKey(prefix, tag)
+		if f.Type.Kind() == reflect.Struct {
+			walkKeys(f.Type, key, fn)
+			continue
+		}
+		fn(key, f.Type)
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// envNames maps env variable names to keys: "_" may stand for a level or be
+// part of a name, so DB_MAX_OPEN_CONNS is db.max_open_conns and not
+// db.max.open.conns. Map keys come after their prefix: LOG_MODULES_GORM is
+// log.modules.gorm, HTTP_RATE_LIMIT_GROUPS_API_REQUESTS
+// http.rate_limit.groups.api.requests. Slices of structs (log.sinks) are file only.
+type envNames struct {
+	keys map[string]string // db_max_open_conns -> db.max_open_conns
+	maps []envMap
+}
+
+type envMap struct {
+	env    string // log_modules
+	key    string // log.modules
+	fields []string
+}
+
+func newEnvNames() *envNames {
+	n := &envNames{keys: map[string]string{}}
+	walkKeys(configType, "", func(key string, t reflect.Type) {
+		env := strings.ReplaceAll(key, ".", "_")
+		switch {
+		case t.Kind() == reflect.Map:
+			m := envMap{env: env, key: key}
+			if t.Elem().Kind() == reflect.Struct {
+				walkKeys(t.Elem(), "", func(field string, _ reflect.Type) {
+					m.fields = append(m.fields, field)
+				})
+			}
+			n.maps = append(n.maps, m)
+		case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Struct:
+		default:
+			n.keys[env] = key
+		}
+	})
+	// longest first, so a prefix never shadows a longer one
+	sort.Slice(n.maps, func(i, j int) bool { return len(n.maps[i].env) > len(n.maps[j].env) })
+	return n
+}
+
+// key returns the config key of the env variable name, "" when it names none.
+func (n *envNames) key(name string) string {
+	name = strings.ToLower(name)
+	if key, ok := n.keys[name]; ok {
+		return key
+	}
+	for _, m := range n.maps {
+		rest, ok := strings.CutPrefix(name, m.env+"_")
+		if !ok || rest == "" {
+			continue
+		}
+		if m.fields == nil {
+			return m.key + "." + rest
+		}
+		for _, field := range m.fields {
+			if entry, ok := strings.CutSuffix(rest, "_"+strings.ReplaceAll(field, ".", "_")); ok && entry != "" {
+				return m.key + "." + entry + "." + field
+			}
+		}
+	}
+	return ""
+}
+
+// unknownKeys returns the keys of v, as loaded from a file, that t does not declare.
+func unknownKeys(v any, t reflect.Type, prefix string) []string {
+	var out []string
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil // wrong types are reported by Unmarshal
+		}
+		for key, val := range m {
+			f, ok := fieldByKey(t, key)
+			if !ok {
+				out = append(out, joinKey(prefix, key))
+				continue
+			}
+			out = append(out, unknownKeys(val, f.Type, joinKey(prefix, key))...)
+		}
+	case reflect.Map:
+		m, _ := v.(map[string]any)
+		for key, val := range m {
+			out = append(out, unknownKeys(val, t.Elem(), joinKey(prefix, key))...)
+		}
+	case reflect.Slice:
+		s, _ := v.([]any)
+		for i, val := range s {
+			out = append(out, unknownKeys(val, t.Elem(), fmt.Sprintf("%s[%d]", prefix, i))...)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func fieldByKey(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		if f := t.Field(i); f.Tag.Get("koanf") == key {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// suggest returns a declared key close to the unknown one, e.g. app.port for
+// app.prot, or "".
+func suggest(unknown string) string {
+	parent, name := "", listIndex.ReplaceAllString(unknown, "")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		parent, name = name[:i], name[i+1:]
+	}
+	best, bestDist := "", 3 // at most 2 edits
+	for _, key := range declaredKeys(configType, "") {
+		keyParent, keyName := "", key
+		if i := strings.LastIndex(key, "."); i >= 0 {
+			keyParent, keyName = key[:i], key[i+1:]
+		}
+		if keyParent != parent {
+			continue
+		}
+		if d := editDistance(name, keyName); d < bestDist {
+			best, bestDist = keyName, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return unknown[:strings.LastIndex(unknown, ".")+1] + best
+}
+
+// declaredKeys returns every key of t, sections included.
+func declaredKeys(t reflect.Type, prefix string) []string {
+	var out []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if tag := f.Tag.Get("koanf"); tag != "" {
+			key := joinKey(prefix, tag)
+			out = append(out, key)
+			switch {
+			case f.Type.Kind() == reflect.Struct:
+				out = append(out, declaredKeys(f.Type, key)...)
+			case f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.Struct:
+				out = append(out, declaredKeys(f.Type.Elem(), key)...)
+			}
+		}
+	}
+	return out
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
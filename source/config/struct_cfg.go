@@ -1,17 +1,163 @@
 package config
 
+import "time"
+
+// Config is loaded by LoadConfig. The validate tags are checked by Validate,
+// with the custom rules loglevel, dsn and regexp.
 type Config struct {
 	App struct {
-		Name    string `koanf:"name"`
-		Mode    string `koanf:"mode"`
-		Port    int    `koanf:"port"`
+		Name    string `koanf:"name" validate:"required"`
+		Env     string `koanf:"env" validate:"required,ne=override"`                // profile, selects the config.<env>.yaml overlay
+		Mode    string `koanf:"mode" validate:"omitempty,oneof=debug release test"` // gin mode
+		Port    int    `koanf:"port" validate:"min=1,max=65535"`
 		Version string `koanf:"version"`
 	} `koanf:"app"`
+	HTTP struct {
+		ShutdownTimeout time.Duration `koanf:"shutdown_timeout" validate:"gt=0"`
+		DrainDelay      time.Duration `koanf:"drain_delay" validate:"min=0"`
+
+		// error body format: envelope (default) or problem (RFC 7807);
+		// clients sending Accept: application/problem+json always get problem
+		ErrorFormat     string `koanf:"error_format" validate:"omitempty,oneof=envelope problem"`
+		ProblemTypeBase string `koanf:"problem_type_base"` // "type" is base + code, about:blank when empty
+
+		RateLimit struct {
+			Enabled bool `koanf:"enabled"`
+			// token bucket per route group: "api" covers every /api/v1 route
+			// before authentication, "users", "customers" and "admin" their group
+			Groups map[string]RateLimitRule `koanf:"groups" validate:"dive"`
+		} `koanf:"rate_limit"`
+
+		Security struct {
+			CORS struct {
+				AllowedOrigins   []string      `koanf:"allowed_origins"` // exact origins or "*"; empty disables CORS
+				AllowedMethods   []string      `koanf:"allowed_methods"`
+				AllowedHeaders   []string      `koanf:"allowed_headers"`
+				ExposedHeaders   []string      `koanf:"exposed_headers"`
+				AllowCredentials bool          `koanf:"allow_credentials"`
+				MaxAge           time.Duration `koanf:"max_age"` // how long browsers cache a preflight
+			} `koanf:"cors"`
+			HSTS struct {
+				MaxAge            time.Duration `koanf:"max_age"` // 0 omits Strict-Transport-Security
+				IncludeSubdomains bool          `koanf:"include_subdomains"`
+				Preload           bool          `koanf:"preload"`
+			} `koanf:"hsts"`
+			ContentTypeNosniff    bool   `koanf:"content_type_nosniff"`
+			FrameOptions          string `koanf:"frame_options"`           // DENY, SAMEORIGIN or "" to omit
+			ContentSecurityPolicy string `koanf:"content_security_policy"` // "" omits the header
+
+			// proxies whose X-Forwarded-For is believed for the client IP,
+			// as IPs or CIDRs; empty trusts none and uses the peer address
+			TrustedProxies []string `koanf:"trusted_proxies" validate:"dive,ip|cidr"`
+		} `koanf:"security"`
+	} `koanf:"http"`
 	DB struct {
-		DSN string `koanf:"dsn"`
+		DSN              string        `koanf:"dsn" validate:"required,dsn"`
+		MaxOpenConns     int           `koanf:"max_open_conns" validate:"min=1"`
+		MaxIdleConns     int           `koanf:"max_idle_conns" validate:"min=0"`
+		ConnMaxLifetime  time.Duration `koanf:"conn_max_lifetime"`
+		ConnMaxIdleTime  time.Duration `koanf:"conn_max_idle_time"`
+		ConnectTimeout   time.Duration `koanf:"connect_timeout"`
+		StatementTimeout time.Duration `koanf:"statement_timeout"` // 0 keeps the server default
+		ApplicationName  string        `koanf:"application_name"`
+
+		// read replicas, reads are routed round-robin to the healthy ones
+		Replicas             []string      `koanf:"replicas" validate:"dive,dsn"`
+		ReplicaCheckInterval time.Duration `koanf:"replica_check_interval"`
 	} `koanf:"db"`
+	Auth struct {
+		Enabled bool `koanf:"enabled"` // false: every request runs as an anonymous principal with all scopes; refused in release mode
+		JWT     struct {
+			HMACSecret    string        `koanf:"hmac_secret"`     // HS256
+			PublicKeyFile string        `koanf:"public_key_file"` // PEM, RS256 or ES256
+			JWKSFile      string        `koanf:"jwks_file"`       // local JWKS, keys picked by kid
+			Issuer        string        `koanf:"issuer"`
+			Audience      string        `koanf:"audience"`
+			Leeway        time.Duration `koanf:"leeway"` // clock skew allowed on exp/nbf
+		} `koanf:"jwt"`
+		APIKey struct {
+			CacheTTL time.Duration `koanf:"cache_ttl"` // how long a lookup, or a revoke on another instance, takes to be seen
+		} `koanf:"api_key"`
+		RBAC struct {
+			CacheTTL time.Duration `koanf:"cache_ttl"` // how long a user's role permissions are cached
+		} `koanf:"rbac"`
+	} `koanf:"auth"`
+	Health struct {
+		Timeout  time.Duration `koanf:"timeout" validate:"gt=0"`    // per check
+		CacheTTL time.Duration `koanf:"cache_ttl" validate:"min=0"` // how long a check result is reused across probes
+	} `koanf:"health"`
+	Admin struct {
+		// separate listener for operational endpoints such as /metrics;
+		// keep its port off the public network
+		Enabled bool `koanf:"enabled"`
+		Port    int  `koanf:"port"`
+	} `koanf:"admin"`
+	Tracing struct {
+		Enabled     bool    `koanf:"enabled"`
+		Exporter    string  `koanf:"exporter" validate:"oneof=otlp stdout file"`
+		Endpoint    string  `koanf:"endpoint"`                            // OTLP/HTTP host:port, OTEL_EXPORTER_OTLP_ENDPOINT when empty
+		Insecure    bool    `koanf:"insecure"`                            // plain HTTP to the collector
+		File        string  `koanf:"file"`                                // target of the file exporter
+		SampleRatio float64 `koanf:"sample_ratio" validate:"min=0,max=1"` // share of new traces kept; an incoming sampled flag wins
+	} `koanf:"tracing"`
 	Log struct {
-		Level         string `koanf:"level"`
+		Level         string `koanf:"level" validate:"loglevel"`
 		PrettyConsole bool   `koanf:"pretty_console"`
+
+		// levels overriding Level for a module, e.g. gorm: debug for all SQL
+		Modules map[string]string `koanf:"modules" validate:"dive,loglevel"`
+
+		// outputs, every event goes to each sink at or above its level;
+		// empty means stdout, console formatted when pretty_console is set
+		Sinks []LogSink `koanf:"sinks" validate:"dive"`
+
+		// per level, Burst events a Period are kept, then 1 in Thereafter
+		// (0 drops the rest); levels above MaxLevel are never sampled
+		Sampling struct {
+			Enabled    bool          `koanf:"enabled"`
+			Burst      uint32        `koanf:"burst"`
+			Period     time.Duration `koanf:"period"`
+			Thereafter uint32        `koanf:"thereafter"`
+			MaxLevel   string        `koanf:"max_level" validate:"loglevel"`
+		} `koanf:"sampling"`
+
+		// regular expressions scrubbed from every log line, replaced by
+		// [name]; merged over the built-in email and phone patterns, an
+		// empty pattern turns a built-in off
+		Redact struct {
+			Enabled  bool              `koanf:"enabled"`
+			Patterns map[string]string `koanf:"patterns" validate:"dive,regexp"`
+		} `koanf:"redact"`
+
+		// SQL logging, at debug for every statement and warn for slow ones
+		SlowQuery time.Duration `koanf:"slow_query"` // 0 turns slow query warnings off
+		RedactSQL bool          `koanf:"redact_sql"` // log placeholders instead of bound values
 	} `koanf:"log"`
+
+	sources map[string]string // key -> where its value came from, see Source
+}
+
+// RateLimitRule allows Requests per Period with bursts up to Burst, counted
+// separately for every caller identity.
+type RateLimitRule struct {
+	Requests int           `koanf:"requests" validate:"min=1"`
+	Period   time.Duration `koanf:"period" validate:"gt=0"`
+	Burst    int           `koanf:"burst" validate:"min=0"`                    // defaults to requests
+	By       string        `koanf:"by" validate:"omitempty,oneof=identity ip"` // identity (api key, JWT subject, else IP; default) or ip
+}
+
+// LogSink is one log output.
+type LogSink struct {
+	Type   string `koanf:"type" validate:"oneof=stdout file syslog ring"`  // stdout, file, syslog (RFC 5424 lines in a file) or ring (in memory, see /admin/logs)
+	Level  string `koanf:"level" validate:"omitempty,loglevel"`            // events below are dropped, "" keeps all
+	Format string `koanf:"format" validate:"omitempty,oneof=json console"` // json or console, stdout and file only
+
+	// file and syslog, rotated by size
+	Path       string        `koanf:"path"`
+	MaxSizeMB  int           `koanf:"max_size_mb" validate:"min=0"` // default 100
+	MaxAge     time.Duration `koanf:"max_age" validate:"min=0"`     // rotated files older are deleted, 0 keeps them
+	MaxBackups int           `koanf:"max_backups" validate:"min=0"` // rotated files kept, 0 keeps all
+	Compress   bool          `koanf:"compress"`
+
+	Size int `koanf:"size" validate:"min=0"` // ring entries, default 1000
 }
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	// report koanf keys ("max_open_conns") instead of Go names
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return f.Tag.Get("koanf")
+	})
+	v.RegisterValidation("loglevel", func(fl validator.FieldLevel) bool {
+		_, err := zerolog.ParseLevel(fl.Field().String())
+		return err == nil && fl.Field().String() != ""
+	})
+	v.RegisterValidation("dsn", func(fl validator.FieldLevel) bool {
+		_, err := pgx.ParseConfig(fl.Field().String())
+		return err == nil
+	})
+	v.RegisterValidation("regexp", func(fl validator.FieldLevel) bool {
+		_, err := regexp.Compile(fl.Field().String())
+		return err == nil
+	})
+	return v
+}
+
+// Validate reports every broken setting at once, each with the key and the
+// source of its value, e.g.
+//
+//	app.port (env APP_PORT): must be at most 65535, got 70000
+//
+// Field rules are the validate tags on Config; the rules spanning fields are below.
+func Validate(c *Config) error {
+	var errs []error
+	fail := func(key, msg string) {
+		errs = append(errs, fmt.Errorf("%s (%s): %s", key, c.Source(key), msg))
+	}
+
+	if err := validate.Struct(c); err != nil {
+		var verrs validator.ValidationErrors
+		if !errors.As(err, &verrs) {
+			return err
+		}
+		for _, fe := range verrs {
+			fail(fieldKey(fe.Namespace()), message(fe))
+		}
+	}
+
+	if c.Admin.Enabled && (c.Admin.Port < 1 || c.Admin.Port > 65535 || c.Admin.Port == c.App.Port) {
+		fail("admin.port", fmt.Sprintf("%d is out of range 1-65535 or equals app.port", c.Admin.Port))
+	}
+	switch strings.ToUpper(c.HTTP.Security.FrameOptions) {
+	case "", "DENY", "SAMEORIGIN":
+	default:
+		fail("http.security.frame_options", fmt.Sprintf("%q is not one of DENY, SAMEORIGIN", c.HTTP.Security.FrameOptions))
+	}
+	if cors := c.HTTP.Security.CORS; cors.AllowCredentials && slices.Contains(cors.AllowedOrigins, "*") {
+		fail("http.security.cors.allow_credentials", `cannot be true with allowed_origins "*", list the origins instead`)
+	}
+	if !c.Auth.Enabled && c.App.Mode == "release" {
+		fail("auth.enabled", "must be true in release mode, a disabled auth lets anyone call the admin routes")
+	}
+	if t := c.Tracing; t.Enabled && t.Exporter == "file" && t.File == "" {
+		fail("tracing.file", "required by the file exporter")
+	}
+	for i, sink := range c.Log.Sinks {
+		if (sink.Type == "file" || sink.Type == "syslog") && sink.Path == "" {
+			fail(fmt.Sprintf("log.sinks[%d].path", i), "required by "+sink.Type+" sinks")
+		}
+	}
+	if s := c.Log.Sampling; s.Enabled && (s.Burst == 0 || s.Period <= 0) {
+		fail("log.sampling", "burst and period must be positive")
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateServe checks what only serving needs on top of Validate: a key to
+// verify bearer tokens with. migrate, seed and routes verify no token, so
+// they run without one.
+func ValidateServe(c *Config) error {
+	if jwt := c.Auth.JWT; c.Auth.Enabled && jwt.HMACSecret == "" && jwt.PublicKeyFile == "" && jwt.JWKSFile == "" {
+		return fmt.Errorf("auth.jwt (%s): one of hmac_secret, public_key_file or jwks_file is required when auth is enabled", c.Source("auth.jwt"))
+	}
+	return nil
+}
+
+// fieldKey turns a validator namespace into a config key:
+// Config.log.modules[gorm] is log.modules.gorm, list indexes stay.
+func fieldKey(namespace string) string {
+	_, key, _ := strings.Cut(namespace, ".")
+	var b strings.Builder
+	for {
+		open := strings.IndexByte(key, '[')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(key[open:], ']') + open
+		b.WriteString(key[:open])
+		if idx := key[open+1 : end]; strings.Trim(idx, "0123456789") == "" {
+			b.WriteString(key[open : end+1])
+		} else {
+			b.WriteString("." + idx)
+		}
+		key = key[end+1:]
+	}
+	b.WriteString(key)
+	return b.String()
+}
+
+func message(fe validator.FieldError) string {
+	got := fmt.Sprintf(", got %v", fe.Value())
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min", "gte":
+		return "must be at least " + fe.Param() + got
+	case "max", "lte":
+		return "must be at most " + fe.Param() + got
+	case "gt":
+		return "must be greater than " + fe.Param() + got
+	case "ne":
+		return fmt.Sprintf("%q is reserved", fe.Value())
+	case "oneof":
+		return fmt.Sprintf("%q is not one of %s", fe.Value(), strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "loglevel":
+		return fmt.Sprintf("%q is not one of trace, debug, info, warn, error, fatal, panic, disabled", fe.Value())
+	case "dsn":
+		return "is not a valid PostgreSQL DSN" // the value is not echoed, it may hold a password
+	case "regexp":
+		return fmt.Sprintf("%q is not a valid regular expression", fe.Value())
+	case "ip|cidr":
+		return fmt.Sprintf("%q is not an IP or CIDR", fe.Value())
+	default:
+		return fmt.Sprintf("fails %s%s", fe.Tag(), got)
+	}
+}
@@ -0,0 +1,14 @@
+package get_log_level
+
+import (
+	"github.com/gin-gonic/gin"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+)
+
+// Impl returns the log levels in effect on this instance.
+//
+//	GET /api/v1/admin/log-level
+func (h *Handler) Impl(c *gin.Context) {
+	httpresputils.HttpRespOK(c, logger.CurrentLevels(), nil)
+}
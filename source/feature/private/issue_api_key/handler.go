@@ -0,0 +1,16 @@
+package issue_api_key
+
+import (
+	"github.com/gin-gonic/gin"
+	apikeyrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/api_key_repo"
+)
+
+type Handler struct {
+	repo Repositories
+}
+
+func NewHandler(apiKeyRepo *apikeyrepo.APIKeyRepo) gin.HandlerFunc {
+	repo := injectRepository(apiKeyRepo)
+	handler := &Handler{repo: repo}
+	return handler.Impl
+}
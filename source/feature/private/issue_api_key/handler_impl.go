@@ -0,0 +1,75 @@
+package issue_api_key
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	apikeymodel "github.com/i-sub135/go-rest-blueprint/source/common/model/api_key_model"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/rbac"
+)
+
+type issueAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Owner     string     `json:"owner" binding:"required,max=255"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,required,max=100"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+var (
+	errWildcardScope = apperror.BadRequest(`scope "*" cannot be granted to an api key`).WithCode("wildcard_scope")
+	errScopeNotHeld  = apperror.Forbidden("cannot grant a scope you do not hold").WithCode("scope_not_held")
+)
+
+// Impl creates a key and returns its secret. The secret is only ever shown
+// in this response; the database keeps its hash. A key gets no scope its
+// issuer lacks, so admin:api-keys alone cannot mint a more powerful key.
+func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req issueAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpresputils.HttpRespBindError(c, err)
+		return
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		httpresputils.HttpRespError(c, apperror.BadRequest("expires_at must be in the future"))
+		return
+	}
+
+	for _, scope := range req.Scopes {
+		if scope == rbac.Wildcard {
+			httpresputils.HttpRespError(c, errWildcardScope)
+			return
+		}
+		if !rbac.Can(ctx, scope) {
+			httpresputils.HttpRespError(c, errScopeNotHeld.Wrap(fmt.Errorf("scope %q", scope)))
+			return
+		}
+	}
+
+	secret, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	key := apikeymodel.APIKey{
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Owner:     req.Owner,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := h.repo.Create(ctx, &key); err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("%s/%d", c.FullPath(), key.ID))
+	httpresputils.HttpRespCreated(c, gin.H{"api_key": key, "key": secret}, nil)
+}
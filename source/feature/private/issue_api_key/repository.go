@@ -0,0 +1,25 @@
+package issue_api_key
+
+import (
+	"context"
+
+	apikeymodel "github.com/i-sub135/go-rest-blueprint/source/common/model/api_key_model"
+	apikeyrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/api_key_repo"
+)
+
+type Repositories interface {
+	// common repo implement
+	Create(ctx context.Context, key *apikeymodel.APIKey) error
+
+	// internal repo implement
+}
+
+type repositoryImpl struct {
+	*apikeyrepo.APIKeyRepo // Embedded shared repo
+}
+
+func injectRepository(apiKeyRepo *apikeyrepo.APIKeyRepo) Repositories {
+	return &repositoryImpl{
+		APIKeyRepo: apiKeyRepo,
+	}
+}
@@ -0,0 +1 @@
+package issue_api_key
@@ -0,0 +1,40 @@
+package list_api_keys
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/paginate"
+	apikeyrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/api_key_repo"
+)
+
+// Impl lists api keys page by page, never their secrets.
+//
+//	GET /api/v1/admin/api-keys?owner=billing-service&include_revoked=true
+func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	page, err := paginate.FromQuery(c, apikeyrepo.ListSortFields, "created_at")
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest(err.Error()).Wrap(err))
+		return
+	}
+
+	filter := apikeyrepo.ListFilter{Owner: c.Query("owner")}
+	if v := c.Query("include_revoked"); v != "" {
+		if filter.IncludeRevoked, err = strconv.ParseBool(v); err != nil {
+			httpresputils.HttpRespError(c, apperror.BadRequest("include_revoked must be true or false").Wrap(err))
+			return
+		}
+	}
+
+	keys, meta, err := h.repo.List(ctx, filter, page)
+	if err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	httpresputils.HttpRespOKWithMeta(c, keys, meta)
+}
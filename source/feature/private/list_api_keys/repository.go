@@ -0,0 +1,26 @@
+package list_api_keys
+
+import (
+	"context"
+
+	"github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/paginate"
+	apikeymodel "github.com/i-sub135/go-rest-blueprint/source/common/model/api_key_model"
+	apikeyrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/api_key_repo"
+)
+
+type Repositories interface {
+	// common repo implement
+	List(ctx context.Context, filter apikeyrepo.ListFilter, page paginate.Params) (*[]apikeymodel.APIKey, *paginate.Meta, error)
+
+	// internal repo implement
+}
+
+type repositoryImpl struct {
+	*apikeyrepo.APIKeyRepo // Embedded shared repo
+}
+
+func injectRepository(apiKeyRepo *apikeyrepo.APIKeyRepo) Repositories {
+	return &repositoryImpl{
+		APIKeyRepo: apiKeyRepo,
+	}
+}
@@ -0,0 +1 @@
+package list_api_keys
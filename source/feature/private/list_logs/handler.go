@@ -0,0 +1,12 @@
+package list_logs
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct{}
+
+func NewHandler() gin.HandlerFunc {
+	handler := &Handler{}
+	return handler.Impl
+}
@@ -0,0 +1,49 @@
+package list_logs
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+var errNoRing = apperror.NotFound("no ring log sink configured").WithCode("log_ring_disabled")
+
+// Impl returns the newest events of this instance's ring sink, oldest first.
+//
+//	GET /api/v1/admin/logs?level=warn&limit=100
+func (h *Handler) Impl(c *gin.Context) {
+	ring := logger.RingSink()
+	if ring == nil {
+		httpresputils.HttpRespError(c, errNoRing)
+		return
+	}
+
+	level := zerolog.TraceLevel
+	if v := c.Query("level"); v != "" {
+		var err error
+		if level, err = zerolog.ParseLevel(v); err != nil {
+			httpresputils.HttpRespError(c, apperror.BadRequest("invalid level").Wrap(err))
+			return
+		}
+	}
+	limit := defaultLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxLimit {
+			httpresputils.HttpRespError(c, apperror.BadRequest("limit must be between 1 and "+strconv.Itoa(maxLimit)))
+			return
+		}
+		limit = n
+	}
+
+	httpresputils.HttpRespOK(c, ring.Entries(level, limit), nil)
+}
@@ -0,0 +1,18 @@
+package revoke_api_key
+
+import (
+	"github.com/gin-gonic/gin"
+	apikeyrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/api_key_repo"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+)
+
+type Handler struct {
+	repo Repositories
+	keys *auth.APIKeyCache
+}
+
+func NewHandler(apiKeyRepo *apikeyrepo.APIKeyRepo, keys *auth.APIKeyCache) gin.HandlerFunc {
+	repo := injectRepository(apiKeyRepo)
+	handler := &Handler{repo: repo, keys: keys}
+	return handler.Impl
+}
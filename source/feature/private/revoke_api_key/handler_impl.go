@@ -0,0 +1,38 @@
+package revoke_api_key
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+)
+
+// Impl revokes a key; the row is kept for auditing. Revoking twice is a no-op.
+func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest("invalid api key ID").Wrap(err))
+		return
+	}
+
+	key, err := h.repo.GetByID(ctx, uint(id))
+	if err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	if key.RevokedAt == nil {
+		now := time.Now()
+		if err := h.repo.Revoke(ctx, key, now); err != nil {
+			httpresputils.HttpRespError(c, err)
+			return
+		}
+		key.RevokedAt = &now
+		h.keys.Invalidate(key.KeyHash)
+	}
+
+	httpresputils.HttpRespOK(c, key, nil)
+}
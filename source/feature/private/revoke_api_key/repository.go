@@ -0,0 +1,27 @@
+package revoke_api_key
+
+import (
+	"context"
+	"time"
+
+	apikeymodel "github.com/i-sub135/go-rest-blueprint/source/common/model/api_key_model"
+	apikeyrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/api_key_repo"
+)
+
+type Repositories interface {
+	// common repo implement
+	GetByID(ctx context.Context, id uint) (*apikeymodel.APIKey, error)
+	Revoke(ctx context.Context, key *apikeymodel.APIKey, at time.Time) error
+
+	// internal repo implement
+}
+
+type repositoryImpl struct {
+	*apikeyrepo.APIKeyRepo // Embedded shared repo
+}
+
+func injectRepository(apiKeyRepo *apikeyrepo.APIKeyRepo) Repositories {
+	return &repositoryImpl{
+		APIKeyRepo: apiKeyRepo,
+	}
+}
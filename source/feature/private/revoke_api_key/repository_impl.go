@@ -0,0 +1 @@
+package revoke_api_key
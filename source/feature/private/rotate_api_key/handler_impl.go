@@ -0,0 +1,66 @@
+package rotate_api_key
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/rbac"
+)
+
+var (
+	errRevoked       = apperror.Conflict("api key is revoked").WithCode("api_key_revoked")
+	errWildcardScope = apperror.BadRequest(`scope "*" cannot be granted to an api key`).WithCode("wildcard_scope")
+	errScopeNotHeld  = apperror.Forbidden("cannot grant a scope you do not hold").WithCode("scope_not_held")
+)
+
+// Impl replaces the secret of a key, keeping its id, owner and scopes. The
+// old secret stops working at once on this instance and within the cache
+// TTL on others. Rotating hands out a working secret, so the caller must
+// hold every scope of the key, as when issuing one.
+func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest("invalid api key ID").Wrap(err))
+		return
+	}
+
+	key, err := h.repo.GetByID(ctx, uint(id))
+	if err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+	if key.RevokedAt != nil {
+		httpresputils.HttpRespError(c, errRevoked)
+		return
+	}
+	for _, scope := range key.Scopes {
+		if scope == rbac.Wildcard {
+			httpresputils.HttpRespError(c, errWildcardScope)
+			return
+		}
+		if !rbac.Can(ctx, scope) {
+			httpresputils.HttpRespError(c, errScopeNotHeld.Wrap(fmt.Errorf("scope %q", scope)))
+			return
+		}
+	}
+
+	secret, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+	oldHash := key.KeyHash
+	if err := h.repo.Rotate(ctx, key, prefix, hash); err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+	h.keys.Invalidate(oldHash)
+
+	key.Prefix, key.KeyHash = prefix, hash
+	httpresputils.HttpRespOK(c, gin.H{"api_key": key, "key": secret}, nil)
+}
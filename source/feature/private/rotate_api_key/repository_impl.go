@@ -0,0 +1 @@
+package rotate_api_key
@@ -0,0 +1,53 @@
+package set_log_level
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+)
+
+type setLogLevelRequest struct {
+	Level   string            `json:"level" binding:"required"`
+	Modules map[string]string `json:"modules"`
+	TTL     string            `json:"ttl"` // e.g. "15m"; empty makes the change permanent
+}
+
+// Impl replaces the log levels of this instance only; other replicas keep
+// theirs. With a ttl the previous permanent levels come back after it.
+//
+//	PUT /api/v1/admin/log-level {"level": "info", "modules": {"gorm": "debug"}, "ttl": "15m"}
+func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req setLogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpresputils.HttpRespBindError(c, err)
+		return
+	}
+
+	levels, err := logger.ParseLevels(req.Level, req.Modules)
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest("invalid level: "+err.Error()).Wrap(err))
+		return
+	}
+	var ttl time.Duration
+	if req.TTL != "" {
+		if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl <= 0 {
+			httpresputils.HttpRespError(c, apperror.BadRequest("ttl must be a positive duration such as 15m"))
+			return
+		}
+	}
+
+	// logged under the old levels, the new ones may drop it
+	logger.Warn(ctx).
+		Str("level", req.Level).
+		Interface("modules", req.Modules).
+		Dur("ttl", ttl).
+		Msg("changing log levels")
+	logger.SetLevels(levels, ttl)
+
+	httpresputils.HttpRespOK(c, logger.CurrentLevels(), nil)
+}
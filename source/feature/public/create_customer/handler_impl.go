@@ -0,0 +1,64 @@
+package create_customer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	customermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/customer_model"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/redact"
+)
+
+type createCustomerRequest struct {
+	FirstName   string  `json:"first_name" binding:"required,max=100"`
+	LastName    string  `json:"last_name" binding:"required,max=100"`
+	Email       string  `json:"email" binding:"required,email,max=255"`
+	Phone       string  `json:"phone" binding:"omitempty,e164"`
+	Address     string  `json:"address" binding:"omitempty,max=500"`
+	City        string  `json:"city" binding:"omitempty,max=100"`
+	Country     string  `json:"country" binding:"omitempty,max=100"`
+	DateOfBirth *string `json:"date_of_birth" binding:"omitempty,datetime=2006-01-02"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req createCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpresputils.HttpRespBindError(c, err)
+		return
+	}
+
+	customer := customermodel.Customer{
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Email:     req.Email,
+		Phone:     req.Phone,
+		Address:   req.Address,
+		City:      req.City,
+		Country:   req.Country,
+		IsActive:  true,
+	}
+	if customer.Country == "" {
+		customer.Country = "Indonesia"
+	}
+	if req.IsActive != nil {
+		customer.IsActive = *req.IsActive
+	}
+	if req.DateOfBirth != nil {
+		dob, _ := time.Parse(time.DateOnly, *req.DateOfBirth) // format checked by the datetime rule
+		customer.DateOfBirth = &dob
+	}
+
+	if err := h.repo.Create(ctx, &customer); err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+	logger.Info(ctx).Object("customer", redact.Object(customer)).Msg("customer created")
+
+	c.Header("Location", fmt.Sprintf("%s/%d", c.FullPath(), customer.ID))
+	httpresputils.HttpRespCreated(c, customer, nil)
+}
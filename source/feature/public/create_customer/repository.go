@@ -0,0 +1,25 @@
+package create_customer
+
+import (
+	"context"
+
+	customermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/customer_model"
+	customerrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/customer_repo"
+)
+
+type Repositories interface {
+	// common repo implement
+	Create(ctx context.Context, customer *customermodel.Customer) error
+
+	// internal repo implement
+}
+
+type repositoryImpl struct {
+	*customerrepo.CustomerRepo // Embedded shared repo
+}
+
+func injectRepository(customerRepo *customerrepo.CustomerRepo) Repositories {
+	return &repositoryImpl{
+		CustomerRepo: customerRepo,
+	}
+}
@@ -0,0 +1,16 @@
+package create_user
+
+import (
+	"github.com/gin-gonic/gin"
+	userrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/user_repo"
+)
+
+type Handler struct {
+	repo Repositories
+}
+
+func NewHandler(userRepo *userrepo.UserRepo) gin.HandlerFunc {
+	repo := injectRepository(userRepo)
+	handler := &Handler{repo: repo}
+	return handler.Impl
+}
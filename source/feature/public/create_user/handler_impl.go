@@ -0,0 +1,33 @@
+package create_user
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	usermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/user_model"
+)
+
+type createUserRequest struct {
+	Name  string `json:"name" binding:"required,min=2,max=100"`
+	Email string `json:"email" binding:"required,email,max=255"`
+}
+
+func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req createUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpresputils.HttpRespBindError(c, err)
+		return
+	}
+
+	user := usermodel.User{Name: req.Name, Email: req.Email}
+	if err := h.repo.Create(ctx, &user); err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("%s/%d", c.FullPath(), user.ID))
+	httpresputils.HttpRespCreated(c, user, nil)
+}
@@ -0,0 +1,32 @@
+package delete_customer
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+)
+
+// Impl soft-deletes a customer, POST /customers/:id/restore brings it back.
+func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest("invalid customer ID").Wrap(err))
+		return
+	}
+
+	if _, err := h.repo.GetByID(ctx, uint(id)); err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	if err := h.repo.Delete(ctx, uint(id)); err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	msg := "customer deleted"
+	httpresputils.HttpRespOK(c, nil, &msg)
+}
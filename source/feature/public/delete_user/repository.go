@@ -0,0 +1,26 @@
+package delete_user
+
+import (
+	"context"
+
+	usermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/user_model"
+	userrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/user_repo"
+)
+
+type Repositories interface {
+	// common repo implement
+	GetByID(ctx context.Context, id uint) (*usermodel.User, error)
+	Delete(ctx context.Context, id uint) error
+
+	// internal repo implement
+}
+
+type repositoryImpl struct {
+	*userrepo.UserRepo // Embedded shared repo
+}
+
+func injectRepository(userRepo *userrepo.UserRepo) Repositories {
+	return &repositoryImpl{
+		UserRepo: userRepo,
+	}
+}
@@ -0,0 +1 @@
+package delete_user
@@ -0,0 +1,73 @@
+package get_all_customer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/paginate"
+	customerrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/customer_repo"
+)
+
+// Impl lists customers page by page.
+//
+//	GET /api/v1/customers?page=1&page_size=20&sort=last_name&order=asc
+//	filters: city, country, is_active, min_age, max_age
+func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	page, err := paginate.FromQuery(c, customerrepo.ListSortFields, "created_at")
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest(err.Error()).Wrap(err))
+		return
+	}
+
+	filter, err := parseFilter(c)
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest(err.Error()).Wrap(err))
+		return
+	}
+
+	customers, meta, err := h.repo.List(ctx, filter, page)
+	if err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	httpresputils.HttpRespOKWithMeta(c, customers, meta)
+}
+
+func parseFilter(c *gin.Context) (customerrepo.ListFilter, error) {
+	filter := customerrepo.ListFilter{
+		City:    c.Query("city"),
+		Country: c.Query("country"),
+	}
+
+	if v := c.Query("is_active"); v != "" {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("is_active must be true or false")
+		}
+		filter.IsActive = &active
+	}
+	for _, bound := range []struct {
+		name string
+		dst  **int
+	}{{"min_age", &filter.MinAge}, {"max_age", &filter.MaxAge}} {
+		v := c.Query(bound.name)
+		if v == "" {
+			continue
+		}
+		age, err := strconv.Atoi(v)
+		if err != nil || age < 0 {
+			return filter, fmt.Errorf("%s must be a non-negative integer", bound.name)
+		}
+		*bound.dst = &age
+	}
+	if filter.MinAge != nil && filter.MaxAge != nil && *filter.MinAge > *filter.MaxAge {
+		return filter, fmt.Errorf("min_age must not exceed max_age")
+	}
+	return filter, nil
+}
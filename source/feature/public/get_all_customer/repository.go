@@ -0,0 +1,26 @@
+package get_all_customer
+
+import (
+	"context"
+
+	"github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/paginate"
+	customermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/customer_model"
+	customerrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/customer_repo"
+)
+
+type Repositories interface {
+	// common repo implement
+	List(ctx context.Context, filter customerrepo.ListFilter, page paginate.Params) (*[]customermodel.Customer, *paginate.Meta, error)
+
+	// internal repo implement
+}
+
+type repositoryImpl struct {
+	*customerrepo.CustomerRepo // Embedded shared repo
+}
+
+func injectRepository(customerRepo *customerrepo.CustomerRepo) Repositories {
+	return &repositoryImpl{
+		CustomerRepo: customerRepo,
+	}
+}
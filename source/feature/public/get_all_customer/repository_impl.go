@@ -0,0 +1 @@
+package get_all_customer
@@ -1,21 +1,80 @@
 package get_all_user
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
 	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
-	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/paginate"
+	userrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/user_repo"
 )
 
+// Impl lists users page by page.
+//
+//	GET /api/v1/users?page=2&page_size=20&sort=created_at&order=desc
+//	GET /api/v1/users?cursor=<next_cursor>&sort=created_at&order=desc
+//	filters: name_contains, email_domain, created_at_gte, created_at_lte (RFC3339 or YYYY-MM-DD)
 func (h *Handler) Impl(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	users, err := h.repo.GetAll(ctx)
+	page, err := paginate.FromQuery(c, userrepo.ListSortFields, "created_at")
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest(err.Error()).Wrap(err))
+		return
+	}
+
+	filter, err := parseFilter(c)
 	if err != nil {
-		errMsg := err.Error()
-		logger.Error().Err(err).Caller().Msg(errMsg)
-		httpresputils.HttpRespBadRequest(c, &errMsg)
+		httpresputils.HttpRespError(c, apperror.BadRequest(err.Error()).Wrap(err))
 		return
 	}
 
-	httpresputils.HttpRespOK(c, users, nil)
+	users, meta, err := h.repo.List(ctx, filter, page)
+	if err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	httpresputils.HttpRespOKWithMeta(c, users, meta)
+}
+
+func parseFilter(c *gin.Context) (userrepo.ListFilter, error) {
+	filter := userrepo.ListFilter{
+		NameContains: c.Query("name_contains"),
+		EmailDomain:  c.Query("email_domain"),
+	}
+
+	if v := c.Query("created_at_gte"); v != "" {
+		t, err := parseTime(v, false)
+		if err != nil {
+			return filter, fmt.Errorf("created_at_gte: %w", err)
+		}
+		filter.CreatedFrom = &t
+	}
+	if v := c.Query("created_at_lte"); v != "" {
+		t, err := parseTime(v, true)
+		if err != nil {
+			return filter, fmt.Errorf("created_at_lte: %w", err)
+		}
+		filter.CreatedTo = &t
+	}
+	return filter, nil
+}
+
+// parseTime accepts RFC3339 or a plain date; a plain date used as an upper
+// bound covers the whole day.
+func parseTime(v string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return t, fmt.Errorf("want RFC3339 or YYYY-MM-DD, got %q", v)
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
 }
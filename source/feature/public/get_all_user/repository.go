@@ -3,13 +3,14 @@ package get_all_user
 import (
 	"context"
 
+	"github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/paginate"
 	usermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/user_model"
 	userrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/user_repo"
 )
 
 type Repositories interface {
 	// common repo implement
-	GetAll(ctx context.Context) (*[]usermodel.User, error)
+	List(ctx context.Context, filter userrepo.ListFilter, page paginate.Params) (*[]usermodel.User, *paginate.Meta, error)
 	/**
 	append methods name for implement internal methode
 		example GetUserByEmail(ctx context.Context) (usermodel.User, error)
@@ -0,0 +1,16 @@
+package get_customer_by_id
+
+import (
+	"github.com/gin-gonic/gin"
+	customerrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/customer_repo"
+)
+
+type Handler struct {
+	repo Repositories
+}
+
+func NewHandler(customerRepo *customerrepo.CustomerRepo) gin.HandlerFunc {
+	repo := injectRepository(customerRepo)
+	handler := &Handler{repo: repo}
+	return handler.Impl
+}
@@ -0,0 +1,26 @@
+package get_customer_by_id
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+)
+
+func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest("invalid customer ID").Wrap(err))
+		return
+	}
+
+	customer, err := h.repo.GetByID(ctx, uint(id))
+	if err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	httpresputils.HttpRespOK(c, customer, nil)
+}
@@ -0,0 +1,25 @@
+package get_customer_by_id
+
+import (
+	"context"
+
+	customermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/customer_model"
+	customerrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/customer_repo"
+)
+
+type Repositories interface {
+	// common repo implement
+	GetByID(ctx context.Context, id uint) (*customermodel.Customer, error)
+
+	// internal repo implement
+}
+
+type repositoryImpl struct {
+	*customerrepo.CustomerRepo // Embedded shared repo
+}
+
+func injectRepository(customerRepo *customerrepo.CustomerRepo) Repositories {
+	return &repositoryImpl{
+		CustomerRepo: customerRepo,
+	}
+}
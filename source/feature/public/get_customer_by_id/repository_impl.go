@@ -0,0 +1 @@
+package get_customer_by_id
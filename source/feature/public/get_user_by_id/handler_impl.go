@@ -1,36 +1,50 @@
 package get_user_by_id
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
 	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
-	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
-	"github.com/i-sub135/go-rest-blueprint/source/service/constant"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/rbac"
 )
 
+var errNotOwnRecord = apperror.Forbidden("you can only read your own user").WithCode("permission_denied")
+
+// Impl returns one user. Callers without users:read:any only get their own record.
 func (h *Handler) Impl(c *gin.Context) {
 	ctx := c.Request.Context()
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		errMsg := "Invalid user ID"
-		logger.Error().Err(err).Caller().Msg(errMsg)
-		httpresputils.HttpRespBadRequest(c, &errMsg)
+		httpresputils.HttpRespError(c, apperror.BadRequest("invalid user ID").Wrap(err))
 		return
 	}
 
-	requestID := c.GetString(constant.RequestIDKey)
+	if !rbac.Can(ctx, "users:read:any") && !isSelf(ctx, uint(id)) {
+		httpresputils.HttpRespError(c, errNotOwnRecord)
+		return
+	}
 
-	h.repo.LogUserAccess(ctx, uint(id), requestID)
+	h.repo.LogUserAccess(ctx, uint(id))
 
 	user, err := h.repo.GetByID(ctx, uint(id))
 	if err != nil {
-		errMsg := err.Error()
-		logger.Error().Err(err).Caller().Msg(errMsg)
-		httpresputils.HttpRespBadRequest(c, &errMsg)
+		httpresputils.HttpRespError(c, err)
 		return
 	}
 
 	httpresputils.HttpRespOK(c, user, nil)
 }
+
+// isSelf reports whether the caller is the user with id.
+func isSelf(ctx context.Context, id uint) bool {
+	p, ok := auth.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	self, ok := p.UserID()
+	return ok && self == id
+}
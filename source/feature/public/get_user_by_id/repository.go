@@ -12,7 +12,7 @@ type Repositories interface {
 	GetByID(ctx context.Context, id uint) (*usermodel.User, error)
 
 	// internal repo implement
-	LogUserAccess(ctx context.Context, userID uint, requesterIP string) error
+	LogUserAccess(ctx context.Context, userID uint) error
 }
 
 type repositoryImpl struct {
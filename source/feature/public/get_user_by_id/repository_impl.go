@@ -6,10 +6,11 @@ import (
 	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
 )
 
-func (r *repositoryImpl) LogUserAccess(ctx context.Context, userID uint, requesterIP string) error {
-	logger.Info().
+// LogUserAccess records the read; request_id, client_ip and the caller come
+// from the request logger in ctx.
+func (r *repositoryImpl) LogUserAccess(ctx context.Context, userID uint) error {
+	logger.Info(ctx).
 		Uint("user_id", userID).
-		Str("requester_ip", requesterIP).
 		Msg("user profile accessed")
 
 	return nil
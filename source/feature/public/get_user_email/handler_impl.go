@@ -1,30 +1,35 @@
 package get_user_email
 
 import (
-	"errors"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
 	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
-	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/rbac"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/redact"
 )
 
+var errNoCustomerRead = apperror.Forbidden("reading customers needs customers:read").WithCode("permission_denied")
+
+// Impl returns a user and the customers sharing their first name. The policy
+// grants the route on users:read:any; the customers also need customers:read.
 func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+	if !rbac.Can(ctx, "customers:read") {
+		httpresputils.HttpRespError(c, errNoCustomerRead)
+		return
+	}
 
 	email := c.Query("email")
 	if email == "" {
-		errMsg := "user email can`t be empty"
-		logger.Error().Err(errors.New(errMsg)).Caller().Msg(errMsg)
-		httpresputils.HttpRespBadRequest(c, &errMsg)
+		httpresputils.HttpRespError(c, apperror.BadRequest("user email can`t be empty"))
 		return
 	}
 
-	ctx := c.Request.Context()
 	user, err := h.repo.GetByEmail(ctx, email)
 	if err != nil {
-		errMsg := err.Error()
-		logger.Error().Err(err).Caller().Msg(errMsg)
-		httpresputils.HttpRespBadRequest(c, &errMsg)
+		httpresputils.HttpRespError(c, err)
 		return
 	}
 
@@ -32,9 +37,7 @@ func (h *Handler) Impl(c *gin.Context) {
 	// Example: "James.Martinez762@outlook.com" -> "James"
 	emailParts := strings.Split(email, "@")
 	if len(emailParts) == 0 {
-		errMsg := "invalid email format"
-		logger.Error().Err(errors.New(errMsg)).Caller().Msg(errMsg)
-		httpresputils.HttpRespBadRequest(c, &errMsg)
+		httpresputils.HttpRespError(c, apperror.BadRequest("invalid email format"))
 		return
 	}
 
@@ -44,16 +47,15 @@ func (h *Handler) Impl(c *gin.Context) {
 
 	custmer, err := h.repo.GetCustomerFirstName(ctx, firstName)
 	if err != nil {
-		errMsg := err.Error()
-		logger.Error().Err(err).Caller().Msg(errMsg)
-		httpresputils.HttpRespBadRequest(c, &errMsg)
+		httpresputils.HttpRespError(c, err)
 		return
 	}
 
+	// the customers only share a first name with the user, mask their details
 	httpresputils.HttpRespOK(c,
 		gin.H{
 			"user":     user,
-			"customer": custmer,
+			"customer": redact.Value(custmer),
 		},
 		nil,
 	)
@@ -1,13 +1,21 @@
 package healtcheck
 
-import "gorm.io/gorm"
+import (
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/health"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/lifecycle"
+	"gorm.io/gorm"
+)
 
 type Handler struct {
-	db *gorm.DB
+	db     *gorm.DB
+	lc     *lifecycle.Manager
+	checks *health.Registry
 }
 
-func NewHandler(db *gorm.DB) *Handler {
+func NewHandler(db *gorm.DB, lc *lifecycle.Manager, checks *health.Registry) *Handler {
 	return &Handler{
-		db: db,
+		db:     db,
+		lc:     lc,
+		checks: checks,
 	}
 }
@@ -1,19 +1,85 @@
 package healtcheck
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
-	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/db"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/health"
 )
 
-func (h *Handler) HealtCheck(c *gin.Context) {
+// Livez answers 200 as long as the process serves HTTP; restart it when not.
+//
+//	GET /livez
+func (h *Handler) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusUp})
+}
 
-	if err := checkConnection(h.db, c.Request.Context()); err != nil {
-		errMsg := err.Error()
-		httpresputils.HttpRespBadGateway(c, &errMsg)
+// Readyz answers 200 when the instance should get traffic: the servers are
+// started, shutdown has not begun and every critical check is up. Only the
+// critical checks are listed.
+//
+//	GET /readyz
+func (h *Handler) Readyz(c *gin.Context) {
+	if status, ok := h.lifecycleStatus(); !ok {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": status})
 		return
 	}
 
-	msg := "db connect ok"
-	httpresputils.HttpRespOK(c, nil, &msg)
+	report := h.checks.Run(c.Request.Context())
+	critical := report.Checks[:0:0]
+	for _, res := range report.Checks {
+		if res.Critical {
+			critical = append(critical, res)
+		}
+	}
+	report.Checks = critical
+	c.JSON(statusCode(report.Status), report)
+}
+
+// HealtCheck is the former health endpoint, kept for existing probes; it
+// answers like Readyz.
+//
+//	GET /health
+func (h *Handler) HealtCheck(c *gin.Context) {
+	h.Readyz(c)
+}
+
+// Details runs every check and adds the pool statistics, for operators
+// rather than load balancers; it is mounted on the admin listener.
+//
+//	GET /health/details
+func (h *Handler) Details(c *gin.Context) {
+	report := h.checks.Run(c.Request.Context())
+	lifecycle, ready := h.lifecycleStatus()
 
+	data := gin.H{
+		"status":    report.Status,
+		"ready":     ready && report.Status == health.StatusUp,
+		"lifecycle": lifecycle,
+		"checks":    report.Checks,
+	}
+	if stats, err := db.Stats(h.db); err == nil {
+		data["db_pool"] = stats
+	}
+	c.JSON(statusCode(report.Status), data)
+}
+
+// lifecycleStatus is starting, draining or running, and whether that allows traffic.
+func (h *Handler) lifecycleStatus() (string, bool) {
+	switch {
+	case h.lc.Draining():
+		return "draining", false
+	case !h.lc.Ready():
+		return "starting", false
+	default:
+		return "running", true
+	}
+}
+
+func statusCode(status string) int {
+	if status != health.StatusUp {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
 }
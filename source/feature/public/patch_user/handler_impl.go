@@ -0,0 +1,77 @@
+package patch_user
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	globutils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+// patchUserRequest is the user after the patch is applied; it must still be valid.
+type patchUserRequest struct {
+	Name  string `json:"name" binding:"required,min=2,max=100"`
+	Email string `json:"email" binding:"required,email,max=255"`
+}
+
+// Impl applies an RFC 7396 JSON Merge Patch, e.g. {"name": "New Name"}.
+func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest("invalid user ID").Wrap(err))
+		return
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(c.ContentType()); mediaType != mergePatchContentType && mediaType != binding.MIMEJSON {
+		errMsg := "Content-Type must be " + mergePatchContentType
+		c.Header("Accept-Patch", mergePatchContentType)
+		httpresputils.HttpRespUnsupportedMediaType(c, &errMsg)
+		return
+	}
+
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest("invalid request body").Wrap(err))
+		return
+	}
+
+	user, err := h.repo.GetByID(ctx, uint(id))
+	if err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	current, _ := json.Marshal(patchUserRequest{Name: user.Name, Email: user.Email})
+	merged, err := globutils.MergePatch(current, patch)
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest("invalid merge patch document").Wrap(err))
+		return
+	}
+
+	var req patchUserRequest
+	if err := json.Unmarshal(merged, &req); err != nil {
+		httpresputils.HttpRespBindError(c, err)
+		return
+	}
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		httpresputils.HttpRespBindError(c, err)
+		return
+	}
+
+	user.Name = req.Name
+	user.Email = req.Email
+	if err := h.repo.Update(ctx, user); err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	httpresputils.HttpRespOK(c, user, nil)
+}
@@ -0,0 +1 @@
+package patch_user
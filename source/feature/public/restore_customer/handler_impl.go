@@ -0,0 +1,40 @@
+package restore_customer
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"gorm.io/gorm"
+)
+
+var errNotDeleted = apperror.Conflict("customer is not deleted").WithCode("customer_not_deleted")
+
+// Impl undoes a soft delete.
+func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest("invalid customer ID").Wrap(err))
+		return
+	}
+
+	customer, err := h.repo.GetByIDWithDeleted(ctx, uint(id))
+	if err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+	if !customer.DeletedAt.Valid {
+		httpresputils.HttpRespError(c, errNotDeleted)
+		return
+	}
+
+	if err := h.repo.Restore(ctx, uint(id)); err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	customer.DeletedAt = gorm.DeletedAt{}
+	httpresputils.HttpRespOK(c, customer, nil)
+}
@@ -0,0 +1,26 @@
+package restore_customer
+
+import (
+	"context"
+
+	customermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/customer_model"
+	customerrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/customer_repo"
+)
+
+type Repositories interface {
+	// common repo implement
+	GetByIDWithDeleted(ctx context.Context, id uint) (*customermodel.Customer, error)
+	Restore(ctx context.Context, id uint) error
+
+	// internal repo implement
+}
+
+type repositoryImpl struct {
+	*customerrepo.CustomerRepo // Embedded shared repo
+}
+
+func injectRepository(customerRepo *customerrepo.CustomerRepo) Repositories {
+	return &repositoryImpl{
+		CustomerRepo: customerRepo,
+	}
+}
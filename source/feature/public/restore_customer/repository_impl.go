@@ -0,0 +1 @@
+package restore_customer
@@ -0,0 +1,18 @@
+package set_customer_active
+
+import (
+	"github.com/gin-gonic/gin"
+	customerrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/customer_repo"
+)
+
+type Handler struct {
+	repo   Repositories
+	active bool
+}
+
+// NewHandler returns the activate (active=true) or deactivate (active=false) handler.
+func NewHandler(customerRepo *customerrepo.CustomerRepo, active bool) gin.HandlerFunc {
+	repo := injectRepository(customerRepo)
+	handler := &Handler{repo: repo, active: active}
+	return handler.Impl
+}
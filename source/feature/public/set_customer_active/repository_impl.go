@@ -0,0 +1 @@
+package set_customer_active
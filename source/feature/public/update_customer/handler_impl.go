@@ -0,0 +1,67 @@
+package update_customer
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/redact"
+)
+
+// updateCustomerRequest is the full representation, PUT replaces every field.
+// is_active is left out on purpose, use the activate/deactivate endpoints.
+type updateCustomerRequest struct {
+	FirstName   string  `json:"first_name" binding:"required,max=100"`
+	LastName    string  `json:"last_name" binding:"required,max=100"`
+	Email       string  `json:"email" binding:"required,email,max=255"`
+	Phone       string  `json:"phone" binding:"omitempty,e164"`
+	Address     string  `json:"address" binding:"omitempty,max=500"`
+	City        string  `json:"city" binding:"omitempty,max=100"`
+	Country     string  `json:"country" binding:"required,max=100"`
+	DateOfBirth *string `json:"date_of_birth" binding:"omitempty,datetime=2006-01-02"`
+}
+
+func (h *Handler) Impl(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		httpresputils.HttpRespError(c, apperror.BadRequest("invalid customer ID").Wrap(err))
+		return
+	}
+
+	var req updateCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httpresputils.HttpRespBindError(c, err)
+		return
+	}
+
+	customer, err := h.repo.GetByID(ctx, uint(id))
+	if err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+
+	customer.FirstName = req.FirstName
+	customer.LastName = req.LastName
+	customer.Email = req.Email
+	customer.Phone = req.Phone
+	customer.Address = req.Address
+	customer.City = req.City
+	customer.Country = req.Country
+	customer.DateOfBirth = nil
+	if req.DateOfBirth != nil {
+		dob, _ := time.Parse(time.DateOnly, *req.DateOfBirth) // format checked by the datetime rule
+		customer.DateOfBirth = &dob
+	}
+
+	if err := h.repo.Update(ctx, customer); err != nil {
+		httpresputils.HttpRespError(c, err)
+		return
+	}
+	logger.Info(ctx).Object("customer", redact.Object(customer)).Msg("customer updated")
+
+	httpresputils.HttpRespOK(c, customer, nil)
+}
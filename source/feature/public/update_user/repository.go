@@ -0,0 +1,26 @@
+package update_user
+
+import (
+	"context"
+
+	usermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/user_model"
+	userrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/user_repo"
+)
+
+type Repositories interface {
+	// common repo implement
+	GetByID(ctx context.Context, id uint) (*usermodel.User, error)
+	Update(ctx context.Context, user *usermodel.User) error
+
+	// internal repo implement
+}
+
+type repositoryImpl struct {
+	*userrepo.UserRepo // Embedded shared repo
+}
+
+func injectRepository(userRepo *userrepo.UserRepo) Repositories {
+	return &repositoryImpl{
+		UserRepo: userRepo,
+	}
+}
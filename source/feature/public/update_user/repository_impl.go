@@ -0,0 +1 @@
+package update_user
@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	apikeymodel "github.com/i-sub135/go-rest-blueprint/source/common/model/api_key_model"
+)
+
+const (
+	apiKeyPrefix    = "gbk_"
+	apiKeyPrefixLen = len(apiKeyPrefix) + 8 // stored and shown, e.g. "gbk_Q2x9fT0a"
+)
+
+// GenerateAPIKey returns a new random key, its display prefix and the hash
+// to store. The key itself is shown once and never stored.
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", "", err
+	}
+	key = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	return key, key[:apiKeyPrefixLen], HashAPIKey(key), nil
+}
+
+// HashAPIKey is the lookup hash of key. Keys carry 256 bits of entropy, so a
+// plain SHA-256 is enough; a slow password hash would only add latency.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyLookup finds a key by hash, returning an error when there is none.
+type APIKeyLookup func(ctx context.Context, hash string) (*apikeymodel.APIKey, error)
+
+type apiKeyEntry struct {
+	key     *apikeymodel.APIKey
+	expires time.Time
+}
+
+// APIKeyCache keeps lookups in process for ttl so authenticated requests do
+// not hit the database each time. Misses are not cached: their hashes come
+// from the client, so caching them would let random keys grow the map without
+// bound; the rate limiter is what keeps them from flooding the database.
+// Invalidate only affects this process; other instances pick up a revoke once
+// their entry expires.
+type APIKeyCache struct {
+	lookup APIKeyLookup
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]apiKeyEntry
+}
+
+func NewAPIKeyCache(lookup APIKeyLookup, ttl time.Duration) *APIKeyCache {
+	return &APIKeyCache{lookup: lookup, ttl: ttl, entries: map[string]apiKeyEntry{}}
+}
+
+// Get returns the key with hash, nil when the lookup reports a not found
+// apperror. Other lookup errors are returned and not cached.
+func (c *APIKeyCache) Get(ctx context.Context, hash string) (*apikeymodel.APIKey, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	e, ok := c.entries[hash]
+	c.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.key, nil
+	}
+
+	key, err := c.lookup(ctx, hash)
+	if err != nil {
+		if apperror.From(err).Kind == apperror.KindNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.evictExpired(now)
+	c.entries[hash] = apiKeyEntry{key: key, expires: now.Add(c.ttl)}
+	c.mu.Unlock()
+	return key, nil
+}
+
+// Invalidate drops hash so the next Get reads the database again.
+func (c *APIKeyCache) Invalidate(hash string) {
+	c.mu.Lock()
+	delete(c.entries, hash)
+	c.mu.Unlock()
+}
+
+// evictExpired bounds the map when it grows; callers hold c.mu.
+func (c *APIKeyCache) evictExpired(now time.Time) {
+	if len(c.entries) < 1024 {
+		return
+	}
+	for hash, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, hash)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTOptions configures a JWTVerifier. At least one of HMACSecret,
+// PublicKeyFile or JWKSFile is required; the accepted algorithms follow
+// from the keys given: HS256 for the secret, RS256 for RSA keys and
+// ES256/ES384/ES512 for EC keys by curve.
+type JWTOptions struct {
+	HMACSecret    string
+	PublicKeyFile string // PEM encoded RSA or EC public key
+	JWKSFile      string // local JSON Web Key Set, keys are picked by "kid"
+	Issuer        string // checked against "iss" when set
+	Audience      string // checked against "aud" when set
+	Leeway        time.Duration
+}
+
+var ErrNoKey = errors.New("auth: no verification key for token")
+
+// JWTVerifier validates bearer tokens and turns them into a Principal.
+type JWTVerifier struct {
+	secret    []byte
+	publicKey any            // used when the token has no kid
+	keys      map[string]any // JWKS keys by kid
+	parser    *jwt.Parser
+}
+
+func NewJWTVerifier(opts JWTOptions) (*JWTVerifier, error) {
+	v := &JWTVerifier{keys: map[string]any{}}
+	var methods []string
+
+	if opts.HMACSecret != "" {
+		v.secret = []byte(opts.HMACSecret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if opts.PublicKeyFile != "" {
+		key, err := readPublicKey(opts.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		v.publicKey = key
+		methods = appendAlg(methods, key)
+	}
+	if opts.JWKSFile != "" {
+		keys, err := readJWKS(opts.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		for kid, key := range keys {
+			v.keys[kid] = key
+			methods = appendAlg(methods, key)
+		}
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("auth: jwt needs hmac_secret, public_key_file or jwks_file")
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(opts.Leeway),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+	v.parser = jwt.NewParser(parserOpts...)
+	return v, nil
+}
+
+// appendAlg adds the algorithm matching key once; keys of other types are ignored.
+func appendAlg(methods []string, key any) []string {
+	var alg string
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		alg = jwt.SigningMethodRS256.Alg()
+	case *ecdsa.PublicKey:
+		switch k.Curve.Params().BitSize {
+		case 384:
+			alg = jwt.SigningMethodES384.Alg()
+		case 521:
+			alg = jwt.SigningMethodES512.Alg()
+		default:
+			alg = jwt.SigningMethodES256.Alg()
+		}
+	default:
+		return methods
+	}
+	if slices.Contains(methods, alg) {
+		return methods
+	}
+	return append(methods, alg)
+}
+
+// Verify checks the signature, exp, nbf, iss and aud of token.
+func (v *JWTVerifier) Verify(token string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.key); err != nil {
+		return nil, err
+	}
+
+	sub, _ := claims.GetSubject()
+	return &Principal{
+		Subject: sub,
+		Scopes:  scopesOf(claims),
+		Method:  "jwt",
+		Claims:  claims,
+	}, nil
+}
+
+func (v *JWTVerifier) key(t *jwt.Token) (any, error) {
+	if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok {
+		if v.secret == nil {
+			return nil, ErrNoKey
+		}
+		return v.secret, nil
+	}
+	if kid, _ := t.Header["kid"].(string); kid != "" {
+		if key, ok := v.keys[kid]; ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("%w: unknown kid %q", ErrNoKey, kid)
+	}
+	if v.publicKey == nil {
+		return nil, ErrNoKey
+	}
+	return v.publicKey, nil
+}
+
+// scopesOf reads "scope" (space separated, RFC 8693) or "scp" (string or list).
+func scopesOf(claims jwt.MapClaims) []string {
+	for _, name := range []string{"scope", "scp"} {
+		switch v := claims[name].(type) {
+		case string:
+			return strings.Fields(v)
+		case []any:
+			scopes := make([]string, 0, len(v))
+			for _, s := range v {
+				if s, ok := s.(string); ok {
+					scopes = append(scopes, s)
+				}
+			}
+			return scopes
+		}
+	}
+	return nil
+}
+
+func readPublicKey(path string) (any, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("auth: read public key: %w", err)
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("auth: %s is not PEM encoded", path)
+	}
+
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("auth: parse certificate: %w", err)
+		}
+		return cert.PublicKey, nil
+	default:
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("auth: parse public key: %w", err)
+		}
+		return key, nil
+	}
+}
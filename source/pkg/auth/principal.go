@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"strconv"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Subject string
+	Scopes  []string
+	Method  string         // "jwt", "api_key", "anonymous"
+	Claims  map[string]any // token claims or key details, nil for anonymous
+}
+
+// AnonymousSubject is the subject of the principal used when auth is disabled.
+const AnonymousSubject = "anonymous"
+
+// Anonymous returns the principal used when auth.enabled is false; its "*"
+// scope becomes rbac.Wildcard, which meets every permission the route policy
+// asks for.
+func Anonymous() *Principal {
+	return &Principal{Subject: AnonymousSubject, Scopes: []string{"*"}, Method: "anonymous"}
+}
+
+// HasScope reports whether p holds scope or the "*" wildcard.
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, "*") || slices.Contains(p.Scopes, scope)
+}
+
+// UserID returns the users.id of a JWT principal whose subject is numeric;
+// other principals are not users.
+func (p *Principal) UserID() (uint, bool) {
+	if p.Method != "jwt" {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(p.Subject, 10, 32)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal stored by WithPrincipal.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
@@ -2,40 +2,145 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/i-sub135/go-rest-blueprint/source/config"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 func Init() (*gorm.DB, error) {
-	// Add connection timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	dsn := config.GetConfig().DB.DSN
+	cfg := config.GetConfig().DB
 
-	// Add connection timeout to DSN if not present
-	if dsn[len(dsn)-1:] != "=" {
-		dsn += " connect_timeout=10"
-	}
-
-	database, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	sqlDB, err := open(cfg.DSN)
 	if err != nil {
 		return nil, err
 	}
 
-	// Test connection with timeout
-	sqlDB, err := database.DB()
+	logCfg := config.GetConfig().Log
+	database, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		TranslateError: true, // unique_violation -> gorm.ErrDuplicatedKey, etc.
+		Logger:         logger.NewGormLogger(logCfg.SlowQuery, logCfg.RedactSQL),
+	})
 	if err != nil {
+		sqlDB.Close()
 		return nil, err
 	}
 
+	// route reads to replicas when configured
+	if len(cfg.Replicas) > 0 {
+		resolver, err := newResolver(cfg.Replicas, cfg.ReplicaCheckInterval)
+		if err != nil {
+			sqlDB.Close()
+			return nil, err
+		}
+		if err := database.Use(resolver); err != nil {
+			resolver.Close()
+			sqlDB.Close()
+			return nil, err
+		}
+	}
+
 	// Ping with context timeout
+	timeout := cfg.ConnectTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 	if err := sqlDB.PingContext(ctx); err != nil {
+		Close(database)
 		return nil, err
 	}
 
 	return database, nil
 }
+
+// open parses dsn, applies connect/statement timeouts and application_name
+// from Config.DB, and returns a sql.DB with the configured pool limits.
+func open(dsn string) (*sql.DB, error) {
+	cfg := config.GetConfig().DB
+
+	pgCfg, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ConnectTimeout > 0 {
+		pgCfg.ConnectTimeout = cfg.ConnectTimeout
+	}
+	if cfg.ApplicationName != "" {
+		pgCfg.RuntimeParams["application_name"] = cfg.ApplicationName
+	}
+	if cfg.StatementTimeout > 0 {
+		pgCfg.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+
+	sqlDB := stdlib.OpenDB(*pgCfg)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return sqlDB, nil
+}
+
+// Close releases the primary sql.DB pool and every replica pool.
+func Close(database *gorm.DB) error {
+	if resolver := ResolverOf(database); resolver != nil {
+		resolver.Close()
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// Ping runs SELECT 1 on the primary, for the database health check.
+func Ping(ctx context.Context, database *gorm.DB) error {
+	var result int
+	if err := database.WithContext(WithPrimary(ctx)).Raw("SELECT 1").Scan(&result).Error; err != nil {
+		return err
+	}
+	if result != 1 {
+		return fmt.Errorf("SELECT 1 returned %d", result)
+	}
+	return nil
+}
+
+type PoolStats struct {
+	MaxOpenConnections int           `json:"max_open_connections"`
+	OpenConnections    int           `json:"open_connections"`
+	InUse              int           `json:"in_use"`
+	Idle               int           `json:"idle"`
+	WaitCount          int64         `json:"wait_count"`
+	WaitDuration       time.Duration `json:"wait_duration_ns"`
+	MaxIdleClosed      int64         `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64         `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64         `json:"max_lifetime_closed"`
+}
+
+// Stats returns sql.DB pool statistics for the health endpoint.
+func Stats(database *gorm.DB) (*PoolStats, error) {
+	sqlDB, err := database.DB()
+	if err != nil {
+		return nil, err
+	}
+	st := sqlDB.Stats()
+	return &PoolStats{
+		MaxOpenConnections: st.MaxOpenConnections,
+		OpenConnections:    st.OpenConnections,
+		InUse:              st.InUse,
+		Idle:               st.Idle,
+		WaitCount:          st.WaitCount,
+		WaitDuration:       st.WaitDuration,
+		MaxIdleClosed:      st.MaxIdleClosed,
+		MaxIdleTimeClosed:  st.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  st.MaxLifetimeClosed,
+	}, nil
+}
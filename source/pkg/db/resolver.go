@@ -0,0 +1,234 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const resolverName = "db:replica_resolver"
+
+type primaryKey struct{}
+
+// WithPrimary marks ctx so every query made with it goes to the primary,
+// giving the caller read-your-writes consistency for the rest of the request.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// UsesPrimary reports whether ctx was marked by WithPrimary.
+func UsesPrimary(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryKey{}).(bool)
+	return v
+}
+
+type replica struct {
+	name    string
+	db      *sql.DB
+	healthy atomic.Bool
+	lastErr atomic.Value // string
+}
+
+// Resolver is a gorm plugin routing reads (Query, Row, SELECT Raw) round-robin
+// across healthy replicas; writes and transactions stay on the primary.
+// Replicas failing a periodic ping are ejected until they answer again, and
+// reads fall back to the primary when none are healthy.
+type Resolver struct {
+	replicas []*replica
+	next     atomic.Uint64
+	interval time.Duration
+	stop     chan struct{}
+	once     sync.Once
+}
+
+type ReplicaStatus struct {
+	Name      string `json:"name"`
+	Healthy   bool   `json:"healthy"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+func newResolver(dsns []string, interval time.Duration) (*Resolver, error) {
+	r := &Resolver{interval: interval, stop: make(chan struct{})}
+	for i, dsn := range dsns {
+		sqlDB, err := open(dsn)
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+		rep := &replica{name: fmt.Sprintf("replica-%d", i), db: sqlDB}
+		rep.healthy.Store(true)
+		r.replicas = append(r.replicas, rep)
+	}
+	return r, nil
+}
+
+func (r *Resolver) Name() string { return resolverName }
+
+func (r *Resolver) Initialize(database *gorm.DB) error {
+	if err := database.Callback().Query().Before("*").Register(resolverName, r.switchRead); err != nil {
+		return err
+	}
+	if err := database.Callback().Row().Before("*").Register(resolverName, r.switchRead); err != nil {
+		return err
+	}
+	if err := database.Callback().Raw().Before("*").Register(resolverName, r.switchRaw); err != nil {
+		return err
+	}
+
+	r.check()
+	go r.watch()
+	return nil
+}
+
+func (r *Resolver) switchRead(database *gorm.DB) {
+	stmt := database.Statement
+	if _, locking := stmt.Clauses["FOR"]; locking {
+		return
+	}
+	// Raw(...).Scan goes through Row/Query callbacks with SQL already set
+	if sqlText := stmt.SQL.String(); sqlText != "" && !isSelect(sqlText) {
+		return
+	}
+	r.route(database)
+}
+
+func (r *Resolver) switchRaw(database *gorm.DB) {
+	if isSelect(database.Statement.SQL.String()) {
+		r.route(database)
+	}
+}
+
+func (r *Resolver) route(database *gorm.DB) {
+	stmt := database.Statement
+	if _, inTx := stmt.ConnPool.(gorm.TxCommitter); inTx {
+		return
+	}
+	if stmt.Context != nil && UsesPrimary(stmt.Context) {
+		return
+	}
+	if rep := r.pick(); rep != nil {
+		stmt.ConnPool = rep.db
+	}
+}
+
+// pick returns the next healthy replica in round-robin order, nil when none.
+func (r *Resolver) pick() *replica {
+	n := len(r.replicas)
+	if n == 0 {
+		return nil
+	}
+	start := r.next.Add(1)
+	for i := 0; i < n; i++ {
+		rep := r.replicas[(start+uint64(i))%uint64(n)]
+		if rep.healthy.Load() {
+			return rep
+		}
+	}
+	return nil
+}
+
+func isSelect(sqlText string) bool {
+	s := strings.TrimSpace(sqlText)
+	if len(s) < 6 || !strings.EqualFold(s[:6], "select") {
+		return false
+	}
+	return !strings.HasSuffix(strings.ToLower(s), "for update")
+}
+
+func (r *Resolver) watch() {
+	if r.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.check()
+		}
+	}
+}
+
+// check pings every replica once and ejects or re-admits it.
+func (r *Resolver) check() {
+	for _, rep := range r.replicas {
+		timeout := r.interval
+		if timeout <= 0 || timeout > 5*time.Second {
+			timeout = 5 * time.Second
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := rep.db.PingContext(ctx)
+		cancel()
+
+		wasHealthy := rep.healthy.Load()
+		rep.healthy.Store(err == nil)
+		if err != nil {
+			rep.lastErr.Store(err.Error())
+			if wasHealthy {
+				logger.Warn().Err(err).Str("replica", rep.name).Msg("replica ejected")
+			}
+			continue
+		}
+		if !wasHealthy {
+			logger.Info().Str("replica", rep.name).Msg("replica healthy again")
+		}
+	}
+}
+
+// Status returns the current health of every replica.
+func (r *Resolver) Status() []ReplicaStatus {
+	list := make([]ReplicaStatus, 0, len(r.replicas))
+	for _, rep := range r.replicas {
+		st := ReplicaStatus{Name: rep.name, Healthy: rep.healthy.Load()}
+		if !st.Healthy {
+			st.LastError, _ = rep.lastErr.Load().(string)
+		}
+		list = append(list, st)
+	}
+	return list
+}
+
+// Check fails while any replica is ejected, naming each with its last error.
+// It reads the state kept by the periodic ping and does not query.
+func (r *Resolver) Check(ctx context.Context) error {
+	var errs []error
+	for _, st := range r.Status() {
+		if !st.Healthy {
+			errs = append(errs, fmt.Errorf("%s: %s", st.Name, st.LastError))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops health checks and closes every replica pool.
+func (r *Resolver) Close() error {
+	var firstErr error
+	r.once.Do(func() {
+		close(r.stop)
+		for _, rep := range r.replicas {
+			if err := rep.db.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	})
+	return firstErr
+}
+
+// ResolverOf returns the replica resolver registered on database, nil when
+// no replicas are configured.
+func ResolverOf(database *gorm.DB) *Resolver {
+	if p, ok := database.Config.Plugins[resolverName]; ok {
+		return p.(*Resolver)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+// Package health runs the dependency checks behind /readyz and
+// /health/details. Components register a named check once at startup; results
+// are cached for CacheTTL so probes from many load balancers do not turn into
+// a query storm.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	defaultTimeout = 5 * time.Second
+)
+
+// Check is a dependency probe. A Critical check failing takes the instance
+// out of rotation; the others only show up in the details.
+type Check struct {
+	Name     string
+	Check    func(ctx context.Context) error
+	Timeout  time.Duration // default 5s
+	Critical bool
+	CacheTTL time.Duration // a result is reused this long, 0 runs the check on every call
+}
+
+// Result is the outcome of a check. LastError and LastErrorAt keep the most
+// recent failure after the check recovers.
+type Result struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Critical    bool       `json:"critical"`
+	Latency     string     `json:"latency"`
+	CheckedAt   time.Time  `json:"checked_at"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+// Report is the outcome of every check; Status is down when a critical check is.
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+// Registry holds the registered checks. The zero value is not usable, see NewRegistry.
+type Registry struct {
+	mu     sync.RWMutex
+	checks []*entry
+}
+
+type entry struct {
+	Check
+
+	mu      sync.Mutex // one run at a time, concurrent callers share its result
+	last    Result
+	expires time.Time
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds c; registering a name twice is a programming error.
+func (r *Registry) Register(c Check) {
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.checks {
+		if e.Name == c.Name {
+			panic(fmt.Sprintf("health: check %q registered twice", c.Name))
+		}
+	}
+	r.checks = append(r.checks, &entry{Check: c})
+}
+
+// Run runs every check concurrently, or reuses its cached result, and
+// returns them in registration order.
+func (r *Registry) Run(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := append([]*entry(nil), r.checks...)
+	r.mu.RUnlock()
+
+	report := Report{Status: StatusUp, Checks: make([]Result, len(checks))}
+	var wg sync.WaitGroup
+	for i, e := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = e.run(ctx)
+		}()
+	}
+	wg.Wait()
+
+	for _, res := range report.Checks {
+		if res.Critical && res.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func (e *entry) run(ctx context.Context) Result {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(e.expires) {
+		return e.last
+	}
+
+	// the result is shared, a caller hanging up must not fail it
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), e.Timeout)
+	defer cancel()
+	err := e.safeCheck(ctx)
+	latency := time.Since(now)
+
+	res := Result{
+		Name:        e.Name,
+		Status:      StatusUp,
+		Critical:    e.Critical,
+		Latency:     latency.Round(time.Microsecond).String(),
+		CheckedAt:   now,
+		LastError:   e.last.LastError,
+		LastErrorAt: e.last.LastErrorAt,
+	}
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s: %w", e.Timeout, err)
+		}
+		res.Status = StatusDown
+		res.LastError = err.Error()
+		res.LastErrorAt = &now
+	}
+	e.last = res
+	e.expires = now.Add(e.CacheTTL)
+	return res
+}
+
+// safeCheck turns a panicking check into a failure.
+func (e *entry) safeCheck(ctx context.Context) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("check panicked: %v", p)
+		}
+	}()
+	return e.Check.Check(ctx)
+}
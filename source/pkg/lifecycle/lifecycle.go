@@ -0,0 +1,157 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+)
+
+type namedServer struct {
+	name string
+	srv  *http.Server
+}
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Manager owns the process lifecycle: it runs the registered HTTP servers,
+// waits for SIGINT/SIGTERM and tears everything down in a fixed order.
+//
+// Shutdown order:
+//  1. flip to draining so /readyz reports unready and load balancers stop routing
+//  2. wait drainDelay so the load balancer notices
+//  3. http.Server.Shutdown on every server, bounded by shutdownTimeout
+//  4. run shutdown hooks in reverse registration order (like defer), bounded
+//     by shutdownTimeout of their own so a slow drain leaves them time
+type Manager struct {
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+	started         atomic.Bool
+	draining        atomic.Bool
+
+	mu      sync.Mutex
+	servers []namedServer
+	hooks   []hook
+}
+
+func New(shutdownTimeout, drainDelay time.Duration) *Manager {
+	return &Manager{
+		shutdownTimeout: shutdownTimeout,
+		drainDelay:      drainDelay,
+	}
+}
+
+// AddServer registers a server to be started by Run and drained on shutdown.
+func (m *Manager) AddServer(name string, srv *http.Server) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.servers = append(m.servers, namedServer{name: name, srv: srv})
+}
+
+// OnShutdown registers a hook executed after all servers stopped accepting
+// requests. Hooks run in reverse registration order, so resources opened
+// first (logger, database) are released last.
+func (m *Manager) OnShutdown(name string, fn func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook{name: name, fn: fn})
+}
+
+// Draining reports whether shutdown has started.
+func (m *Manager) Draining() bool { return m.draining.Load() }
+
+// Ready reports whether Run has started the servers and shutdown has not begun.
+func (m *Manager) Ready() bool { return m.started.Load() && !m.draining.Load() }
+
+// Run starts every registered server and blocks until a termination signal
+// arrives or a server fails, then performs the ordered shutdown.
+func (m *Manager) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	m.mu.Lock()
+	servers := append([]namedServer(nil), m.servers...)
+	m.mu.Unlock()
+
+	errCh := make(chan error, len(servers))
+	for _, s := range servers {
+		go func(s namedServer) {
+			logger.Info().Str("server", s.name).Str("addr", s.srv.Addr).Msg("server listening")
+			if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error().Err(err).Str("server", s.name).Msg("server error")
+				errCh <- err
+			}
+		}(s)
+	}
+	m.started.Store(true)
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		logger.Info().Msg("shutdown signal received")
+	case runErr = <-errCh:
+	}
+	stop()
+
+	return errors.Join(runErr, m.shutdown(servers))
+}
+
+func (m *Manager) shutdown(servers []namedServer) error {
+	m.draining.Store(true)
+	if m.drainDelay > 0 {
+		logger.Info().Dur("drain_delay", m.drainDelay).Msg("draining, waiting for load balancer")
+		time.Sleep(m.drainDelay)
+	}
+
+	var errs []error
+	ctx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
+	for _, s := range servers {
+		if err := s.srv.Shutdown(ctx); err != nil {
+			logger.Error().Err(err).Str("server", s.name).Msg("server shutdown incomplete")
+			errs = append(errs, err)
+		}
+	}
+	cancel()
+
+	return errors.Join(append(errs, m.runHooks())...)
+}
+
+// Abort runs the shutdown hooks without starting or stopping any server, for
+// a startup that fails after resources were registered but before Run.
+func (m *Manager) Abort() error {
+	m.draining.Store(true)
+	return m.runHooks()
+}
+
+// runHooks runs the hooks in reverse registration order, bounded by a
+// shutdownTimeout of their own.
+func (m *Manager) runHooks() error {
+	m.mu.Lock()
+	hooks := append([]hook(nil), m.hooks...)
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+		if err := h.fn(ctx); err != nil {
+			logger.Error().Err(err).Str("hook", h.name).Msg("shutdown hook failed")
+			errs = append(errs, err)
+			continue
+		}
+		logger.Debug().Str("hook", h.name).Msg("shutdown hook done")
+	}
+	return errors.Join(errs...)
+}
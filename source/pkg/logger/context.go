@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+type loggerKey struct{}
+
+// WithContext stores l in ctx for FromContext. Middleware uses it to attach
+// request fields (request_id, route, client_ip, subject) once per request.
+func WithContext(ctx context.Context, l zerolog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns the logger stored by WithContext, or the global Log.
+// Either way it is bound to ctx, so events also get trace_id and span_id.
+func FromContext(ctx context.Context) zerolog.Logger {
+	return fromContext(ctx, "")
+}
+
+// Module is FromContext for a named part of the service: its events carry
+// module and follow the module's level, see SetLevels.
+func Module(ctx context.Context, name string) zerolog.Logger {
+	return fromContext(ctx, name)
+}
+
+func fromContext(ctx context.Context, module string) zerolog.Logger {
+	l, ok := ctx.Value(loggerKey{}).(zerolog.Logger)
+	if !ok {
+		l = Log
+	}
+	lc := l.Level(level(module)).With().Ctx(ctx)
+	if module != "" {
+		lc = lc.Str("module", module)
+	}
+	return lc.Logger()
+}
+
+// With adds fields to the logger in ctx, e.g. once the caller is known.
+func With(ctx context.Context, fields func(zerolog.Context) zerolog.Context) context.Context {
+	l, ok := ctx.Value(loggerKey{}).(zerolog.Logger)
+	if !ok {
+		l = Log
+	}
+	return WithContext(ctx, fields(l.With()).Logger())
+}
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// GormLogger routes gorm's logging through zerolog with the request fields
+// of the statement's context (see FromContext):
+//   - failed statements at error, record-not-found excluded (repositories map it)
+//   - statements slower than the threshold at warn
+//   - every statement at debug, or at info under a mode (db.Debug())
+//
+// Unless gorm sets a mode, what is logged follows the level of module
+// "gorm", so SQL logging can be turned up on its own, see SetLevels. A mode
+// replaces that level, so db.Debug() logs its statements whatever it is.
+type GormLogger struct {
+	slow   time.Duration
+	redact bool
+	mode   gormlogger.LogLevel // 0 follows Log's level
+}
+
+// NewGormLogger returns the logger for gorm.Config.Logger. slow of 0 turns
+// slow query warnings off; redact logs SQL with placeholders, not values.
+func NewGormLogger(slow time.Duration, redact bool) *GormLogger {
+	return &GormLogger{slow: slow, redact: redact}
+}
+
+const gormModule = "gorm"
+
+func (l *GormLogger) log(ctx context.Context) *zerolog.Logger {
+	log := Module(ctx, gormModule)
+	if l.mode != 0 {
+		log = log.Level(zerolog.TraceLevel) // the mode filters instead
+	}
+	return &log
+}
+
+func (l *GormLogger) LogMode(mode gormlogger.LogLevel) gormlogger.Interface {
+	c := *l
+	c.mode = mode
+	return &c
+}
+
+// level maps the "gorm" module level onto gorm's when no mode was set.
+func (l *GormLogger) level() gormlogger.LogLevel {
+	if l.mode != 0 {
+		return l.mode
+	}
+	switch lvl := level(gormModule); {
+	case lvl <= zerolog.DebugLevel:
+		return gormlogger.Info
+	case lvl <= zerolog.WarnLevel:
+		return gormlogger.Warn
+	case lvl == zerolog.ErrorLevel:
+		return gormlogger.Error
+	}
+	return gormlogger.Silent
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...any) {
+	if l.level() >= gormlogger.Info {
+		l.log(ctx).Info().Msgf(msg, args...)
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...any) {
+	if l.level() >= gormlogger.Warn {
+		l.log(ctx).Warn().Msgf(msg, args...)
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...any) {
+	if l.level() >= gormlogger.Error {
+		l.log(ctx).Error().Msgf(msg, args...)
+	}
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	level := l.level()
+	if level == gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	slow := l.slow > 0 && elapsed > l.slow
+
+	log := l.log(ctx)
+	var ev *zerolog.Event
+	switch {
+	case failed && level >= gormlogger.Error:
+		ev = log.Error().Err(err)
+	case slow && level >= gormlogger.Warn:
+		ev = log.Warn().Dur("threshold", l.slow)
+	case level >= gormlogger.Info && l.mode != 0:
+		ev = log.Info() // debug may be below the global level
+	case level >= gormlogger.Info:
+		ev = log.Debug()
+	default:
+		return
+	}
+	if !ev.Enabled() {
+		return
+	}
+
+	msg := "sql"
+	if slow {
+		msg = "slow sql"
+	}
+	sql, rows := fc()
+	ev.Str("sql", sql).
+		Int64("rows", rows).
+		Dur("duration", elapsed).
+		Msg(msg)
+}
+
+// ParamsFilter drops the bound values when redacting, so gorm renders the SQL
+// with its $n placeholders.
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...any) (string, []any) {
+	if l.redact {
+		return sql, nil
+	}
+	return sql, params
+}
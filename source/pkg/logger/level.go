@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Levels is the verbosity of the logger: Level for everything, Modules
+// overriding it for the loggers returned by Module, e.g. "gorm" or "http".
+type Levels struct {
+	Level    zerolog.Level            `json:"level"`
+	Modules  map[string]zerolog.Level `json:"modules"`
+	RevertAt *time.Time               `json:"revert_at"` // when a temporary change ends, nil when permanent
+}
+
+var levels struct {
+	sync.RWMutex
+	current    Levels
+	persistent Levels // what a temporary change reverts to
+	timer      *time.Timer
+	generation uint64 // bumped by SetLevels so a revert already fired for an older change is ignored
+}
+
+// CurrentLevels returns the levels in effect.
+func CurrentLevels() Levels {
+	levels.RLock()
+	defer levels.RUnlock()
+	l := levels.current
+	l.Modules = maps.Clone(l.Modules)
+	return l
+}
+
+// SetLevels replaces the levels. With ttl > 0 the change is temporary and
+// the last permanent levels come back after ttl, so a debug session cannot
+// be left on by mistake.
+func SetLevels(l Levels, ttl time.Duration) {
+	levels.Lock()
+	defer levels.Unlock()
+
+	if levels.timer != nil {
+		levels.timer.Stop()
+		levels.timer = nil
+	}
+	levels.generation++
+	l.Modules = maps.Clone(l.Modules)
+	l.RevertAt = nil
+	if ttl > 0 {
+		at := time.Now().Add(ttl)
+		l.RevertAt = &at
+		generation := levels.generation
+		levels.timer = time.AfterFunc(ttl, func() { revertLevels(generation) })
+	} else {
+		levels.persistent = l
+	}
+	apply(l)
+}
+
+// revertLevels ends the temporary change made at generation. Stop cannot
+// recall a timer that already fired and waits on the lock, hence the check.
+func revertLevels(generation uint64) {
+	levels.Lock()
+	defer levels.Unlock()
+	if generation != levels.generation {
+		return
+	}
+	levels.timer = nil
+	apply(levels.persistent)
+	Log.Warn().Str("level", levels.current.Level.String()).Msg("temporary log levels expired, reverted")
+}
+
+// apply makes l current. The zerolog global level is the lowest level in
+// use so no logger drops an event its own level allows; the loggers handed
+// out by this package filter by Level or their module's level. Callers hold
+// levels.
+func apply(l Levels) {
+	levels.current = l
+	lowest := l.Level
+	for _, lvl := range l.Modules {
+		lowest = min(lowest, lvl)
+	}
+	zerolog.SetGlobalLevel(lowest)
+}
+
+// level returns the level of module, Level when module is "" or not listed.
+func level(module string) zerolog.Level {
+	levels.RLock()
+	defer levels.RUnlock()
+	if lvl, ok := levels.current.Modules[module]; ok && module != "" {
+		return lvl
+	}
+	return levels.current.Level
+}
+
+// ParseLevels parses a level and per-module levels as written in config.
+func ParseLevels(level string, modules map[string]string) (Levels, error) {
+	lvl, err := parseLevel(level)
+	if err != nil {
+		return Levels{}, err
+	}
+	l := Levels{Level: lvl, Modules: make(map[string]zerolog.Level, len(modules))}
+	for name, s := range modules {
+		if l.Modules[name], err = parseLevel(s); err != nil {
+			return Levels{}, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return l, nil
+}
+
+// parseLevel is zerolog.ParseLevel without the empty level.
+func parseLevel(s string) (zerolog.Level, error) {
+	if s == "" {
+		return zerolog.NoLevel, errors.New("empty level")
+	}
+	return zerolog.ParseLevel(s)
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// Ring keeps the last events written to it in memory, for the admin logs
+// endpoint when shipping logs is not an option.
+type Ring struct {
+	mu      sync.Mutex
+	entries []ringEntry
+	next    int
+	full    bool
+}
+
+type ringEntry struct {
+	level zerolog.Level
+	line  json.RawMessage
+}
+
+func NewRing(size int) *Ring {
+	return &Ring{entries: make([]ringEntry, size)}
+}
+
+func (r *Ring) Write(p []byte) (int, error) {
+	return r.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (r *Ring) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	// zerolog reuses p once this returns
+	line := make(json.RawMessage, len(p))
+	copy(line, p)
+	if n := len(line); n > 0 && line[n-1] == '\n' {
+		line = line[:n-1]
+	}
+
+	r.mu.Lock()
+	r.entries[r.next] = ringEntry{level: level, line: line}
+	r.next = (r.next + 1) % len(r.entries)
+	r.full = r.full || r.next == 0
+	r.mu.Unlock()
+	return len(p), nil
+}
+
+// Entries returns up to limit of the newest events at or above level,
+// oldest first.
+func (r *Ring) Entries(level zerolog.Level, limit int) []json.RawMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := r.next
+	if r.full {
+		n = len(r.entries)
+	}
+	out := make([]json.RawMessage, 0, min(n, limit))
+	// walk back from the newest
+	for i := 1; i <= n && len(out) < limit; i++ {
+		e := r.entries[(r.next-i+len(r.entries))%len(r.entries)]
+		if e.level >= level {
+			out = append(out, e.line)
+		}
+	}
+	slices.Reverse(out)
+	return out
+}
@@ -0,0 +1,155 @@
+package logger
+
+import (
+	"io"
+	"maps"
+	"math"
+	"os"
+	"time"
+
+	"github.com/i-sub135/go-rest-blueprint/source/config"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/redact"
+	"github.com/rs/zerolog"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+var (
+	ring    *Ring       // the ring sink, nil when none is configured
+	closers []io.Closer // file sinks, closed by Flush
+)
+
+// RingSink returns the in-memory sink, nil when log.sinks has none.
+func RingSink() *Ring { return ring }
+
+// sinkWriter drops events below min before they reach w.
+type sinkWriter struct {
+	w   io.Writer
+	min zerolog.Level
+}
+
+func (s *sinkWriter) Write(p []byte) (int, error) {
+	return s.w.Write(p)
+}
+
+func (s *sinkWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level < s.min {
+		return len(p), nil
+	}
+	if lw, ok := s.w.(zerolog.LevelWriter); ok {
+		return lw.WriteLevel(level, p)
+	}
+	return s.w.Write(p)
+}
+
+// newOutput builds the writer of every sink in cfg.Log.Sinks.
+func newOutput(cfg *config.Config, prettyConsole bool) zerolog.LevelWriter {
+	sinks := cfg.Log.Sinks
+	if len(sinks) == 0 {
+		format := "json"
+		if prettyConsole {
+			format = "console"
+		}
+		sinks = []config.LogSink{{Type: "stdout", Format: format}}
+	}
+
+	writers := make([]io.Writer, 0, len(sinks))
+	for _, sink := range sinks {
+		var w io.Writer
+		switch sink.Type {
+		case "stdout":
+			w = formatted(os.Stdout, sink.Format, true)
+		case "file":
+			w = formatted(rotating(sink), sink.Format, false)
+		case "syslog":
+			w = newSyslogWriter(rotating(sink), cfg.App.Name)
+		case "ring":
+			size := sink.Size
+			if size <= 0 {
+				size = 1000
+			}
+			ring = NewRing(size)
+			w = ring
+		default:
+			continue
+		}
+		lvl := zerolog.TraceLevel // "" keeps all
+		if sink.Level != "" {
+			lvl, _ = zerolog.ParseLevel(sink.Level)
+		}
+		writers = append(writers, &sinkWriter{w: w, min: lvl})
+	}
+	return zerolog.MultiLevelWriter(writers...)
+}
+
+// scrubbed masks log.redact.patterns in everything written to out.
+func scrubbed(cfg *config.Config, out zerolog.LevelWriter) zerolog.LevelWriter {
+	if !cfg.Log.Redact.Enabled {
+		return out
+	}
+	patterns := maps.Clone(redact.DefaultPatterns)
+	for name, p := range cfg.Log.Redact.Patterns {
+		if p == "" {
+			delete(patterns, name)
+		} else {
+			patterns[name] = p
+		}
+	}
+	s, err := redact.NewScrubber(patterns)
+	if err != nil {
+		// config validation rejects bad patterns; keep the built-in ones
+		s, _ = redact.NewScrubber(redact.DefaultPatterns)
+	}
+	return redact.Writer(out, s)
+}
+
+func formatted(w io.Writer, format string, color bool) io.Writer {
+	if format != "console" {
+		return w
+	}
+	return zerolog.ConsoleWriter{Out: w, TimeFormat: time.RFC3339, NoColor: !color}
+}
+
+// rotating returns the file of a file or syslog sink, rotated by lumberjack.
+func rotating(sink config.LogSink) io.Writer {
+	size := sink.MaxSizeMB
+	if size <= 0 {
+		size = 100
+	}
+	f := &lumberjack.Logger{
+		Filename:   sink.Path,
+		MaxSize:    size,
+		MaxAge:     int(math.Ceil(sink.MaxAge.Hours() / 24)),
+		MaxBackups: sink.MaxBackups,
+		Compress:   sink.Compress,
+	}
+	closers = append(closers, f)
+	return f
+}
+
+// newSampler keeps Burst events per Period for each level up to MaxLevel,
+// then 1 in Thereafter. Each level counts separately, so a flood of 4xx
+// warnings does not crowd out info lines.
+func newSampler(cfg *config.Config) zerolog.Sampler {
+	s := cfg.Log.Sampling
+	maxLevel, err := zerolog.ParseLevel(s.MaxLevel)
+	if err != nil {
+		maxLevel = zerolog.WarnLevel
+	}
+	perLevel := func(level zerolog.Level) zerolog.Sampler {
+		if level > maxLevel {
+			return nil // never sampled
+		}
+		b := &zerolog.BurstSampler{Burst: s.Burst, Period: s.Period}
+		if s.Thereafter > 0 {
+			b.NextSampler = &zerolog.BasicSampler{N: s.Thereafter}
+		}
+		return b
+	}
+	return zerolog.LevelSampler{
+		TraceSampler: perLevel(zerolog.TraceLevel),
+		DebugSampler: perLevel(zerolog.DebugLevel),
+		InfoSampler:  perLevel(zerolog.InfoLevel),
+		WarnSampler:  perLevel(zerolog.WarnLevel),
+		ErrorSampler: perLevel(zerolog.ErrorLevel),
+	}
+}
@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// syslogWriter writes each event as an RFC 5424 line, facility local0, with
+// the JSON event as the message, for collectors tailing a syslog file.
+type syslogWriter struct {
+	out      io.Writer
+	hostname string
+	app      string
+	pid      int
+}
+
+func newSyslogWriter(out io.Writer, app string) *syslogWriter {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	if app == "" {
+		app = "-"
+	}
+	return &syslogWriter{out: out, hostname: hostname, app: app, pid: os.Getpid()}
+}
+
+const facilityLocal0 = 16
+
+func severity(level zerolog.Level) int {
+	switch level {
+	case zerolog.TraceLevel, zerolog.DebugLevel:
+		return 7
+	case zerolog.InfoLevel, zerolog.NoLevel:
+		return 6
+	case zerolog.WarnLevel:
+		return 4
+	case zerolog.ErrorLevel:
+		return 3
+	case zerolog.FatalLevel:
+		return 2
+	}
+	return 0 // panic
+}
+
+func (w *syslogWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (w *syslogWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	// p ends with a newline already
+	_, err := fmt.Fprintf(w.out, "<%d>1 %s %s %s %d - - %s",
+		facilityLocal0*8+severity(level), time.Now().Format(time.RFC3339Nano), w.hostname, w.app, w.pid, p)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -1,27 +1,32 @@
 package logger
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/i-sub135/go-rest-blueprint/source/config"
 	"github.com/i-sub135/go-rest-blueprint/source/service/constant"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var Log zerolog.Logger
 
 // Init initializes global logger.
-// levelStr example: "debug", "info"
-// prettyConsole: when true, use human-friendly console writer
-// callerSkip: frames to skip so caller points to original caller (use 2 if wrapping)
+// prettyConsole: when true and log.sinks is empty, stdout gets the
+// human-friendly console writer
 func Init(prettyConsole bool) {
-	// parse level
-	lvl, err := zerolog.ParseLevel(config.GetConfig().Log.Level)
+	cfg := config.GetConfig()
+
+	// parse levels
+	lvls, err := ParseLevels(cfg.Log.Level, cfg.Log.Modules)
 	if err != nil {
-		lvl = zerolog.InfoLevel
+		lvls = Levels{Level: zerolog.InfoLevel}
 	}
 
 	zerolog.CallerMarshalFunc = func(pc uintptr, file string, line int) string {
@@ -30,50 +35,91 @@ func Init(prettyConsole bool) {
 	zerolog.CallerSkipFrameCount = 3
 	zerolog.TimeFieldFormat = time.RFC3339
 
-	if prettyConsole {
-		out := zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}
-		Log = zerolog.New(out).Level(lvl).With().Timestamp().Str("app", config.GetConfig().App.Name).Str("app_version", config.GetConfig().App.Version).Logger()
-	} else {
-		Log = zerolog.New(os.Stdout).Level(lvl).With().Timestamp().Str("app", config.GetConfig().App.Name).Str("app_version", config.GetConfig().App.Version).Logger()
+	// Log itself lets everything through; SetLevels decides what is written
+	// and each sink drops what is below its own level
+	Log = zerolog.New(scrubbed(cfg, newOutput(cfg, prettyConsole))).Level(zerolog.TraceLevel).With().Timestamp().Str("app", cfg.App.Name).Str("app_version", cfg.App.Version).Logger()
+	if cfg.Log.Sampling.Enabled {
+		Log = Log.Sample(newSampler(cfg))
+	}
+	Log = Log.Hook(traceHook{})
+	SetLevels(lvls, 0)
+}
+
+// traceHook adds trace_id and span_id to events given a context holding a
+// span, e.g. Info().Ctx(c.Request.Context()).
+type traceHook struct{}
+
+func (traceHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	sc := trace.SpanContextFromContext(e.GetCtx())
+	if sc.IsValid() {
+		e.Str("trace_id", sc.TraceID().String()).Str("span_id", sc.SpanID().String())
 	}
 }
 
-// convenience chainable functions
-func Debug() *zerolog.Event { return Log.Debug() }
-func Info() *zerolog.Event  { return Log.Info() }
-func Warn() *zerolog.Event  { return Log.Warn() }
-func Error() *zerolog.Event { return Log.Error() }
+// Flush syncs stdout and closes the file sinks. Call it last during shutdown.
+func Flush() error {
+	var errs []error
+	// pipes and terminals cannot be synced; nothing is buffered there anyway
+	if err := os.Stdout.Sync(); err != nil && !errors.Is(err, syscall.EINVAL) && !errors.Is(err, syscall.ENOTTY) {
+		errs = append(errs, err)
+	}
+	for _, c := range closers {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
 
-// GinZLogger returns middleware that logs request after handler runs.
+// convenience chainable functions; pass the request context to log with its
+// fields, see FromContext: logger.Info(ctx).Msg("...")
+func Debug(ctx ...context.Context) *zerolog.Event { return from(ctx).Debug() }
+func Info(ctx ...context.Context) *zerolog.Event  { return from(ctx).Info() }
+func Warn(ctx ...context.Context) *zerolog.Event  { return from(ctx).Warn() }
+func Error(ctx ...context.Context) *zerolog.Event { return from(ctx).Error() }
+
+func from(ctx []context.Context) *zerolog.Logger {
+	if len(ctx) == 0 || ctx[0] == nil {
+		l := Log.Level(level(""))
+		return &l
+	}
+	l := FromContext(ctx[0])
+	return &l
+}
+
+// GinZLogger, module "http", stores a logger carrying request_id, route and client_ip in the
+// request context for FromContext, then logs the request after the handler
+// runs. Mount it after RequestIDMiddleware.
 func GinZLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		c.Request = c.Request.WithContext(With(c.Request.Context(), func(l zerolog.Context) zerolog.Context {
+			return l.Str(constant.RequestIDKey, c.GetString(constant.RequestIDKey)).
+				Str("route", route).
+				Str("client_ip", c.ClientIP())
+		}))
+
 		c.Next()
 		dur := time.Since(start)
 		status := c.Writer.Status()
-		method := c.Request.Method
-		path := c.FullPath()
-		if path == "" {
-			path = c.Request.URL.Path
-		}
-		ip := c.ClientIP()
 
+		// the handlers may have replaced the context, e.g. with the caller's subject
+		l := Module(c.Request.Context(), "http")
 		var ev *zerolog.Event
 		switch {
 		case status >= 500:
-			ev = Log.Error()
+			ev = l.Error()
 		case status >= 400:
-			ev = Log.Warn()
+			ev = l.Warn()
 		case status >= 300:
-			ev = Log.Debug()
+			ev = l.Debug()
 		default:
-			ev = Log.Info()
+			ev = l.Info()
 		}
 
-		ev.Str("method", method).
-			Str("path", path).
-			Str("client_ip", ip).
-			Str(constant.RequestIDKey, c.GetString(constant.RequestIDKey)).
+		ev.Str("method", c.Request.Method).
 			Int("status", status).
 			Dur("latency", dur).
 			Msg("http request")
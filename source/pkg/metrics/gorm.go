@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+const (
+	pluginName    = "metrics"
+	repositoryKey = "metrics:repository"
+	startKey      = "metrics:start"
+)
+
+var (
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Database query latency by repository and operation.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"repository", "operation"})
+
+	dbQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_errors_total",
+		Help: "Failed database queries by repository and operation, not found excluded.",
+	}, []string{"repository", "operation"})
+)
+
+// Repository returns db labelled with name for db_query_* metrics; a
+// repository constructor keeps the result and queries through it. A nil db,
+// as used by the routes command, stays nil.
+func Repository(db *gorm.DB, name string) *gorm.DB {
+	if db == nil {
+		return nil
+	}
+	return db.Set(repositoryKey, name).Session(&gorm.Session{})
+}
+
+// InstrumentDB installs GormPlugin on database and exports its sql.DB pool
+// statistics as go_sql_* metrics.
+func InstrumentDB(database *gorm.DB) error {
+	if err := database.Use(GormPlugin{}); err != nil {
+		return err
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		return err
+	}
+	return Registry.Register(collectors.NewDBStatsCollector(sqlDB, "primary"))
+}
+
+// GormPlugin times every statement into db_query_duration_seconds, labelled
+// by the repository set with Repository ("other" when unset).
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string { return pluginName }
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register(pluginName+":start_create", start),
+		cb.Create().After("*").Register(pluginName+":observe_create", observe("create")),
+		cb.Query().Before("*").Register(pluginName+":start_query", start),
+		cb.Query().After("*").Register(pluginName+":observe_query", observe("query")),
+		cb.Update().Before("*").Register(pluginName+":start_update", start),
+		cb.Update().After("*").Register(pluginName+":observe_update", observe("update")),
+		cb.Delete().Before("*").Register(pluginName+":start_delete", start),
+		cb.Delete().After("*").Register(pluginName+":observe_delete", observe("delete")),
+		cb.Row().Before("*").Register(pluginName+":start_row", start),
+		cb.Row().After("*").Register(pluginName+":observe_row", observe("row")),
+		cb.Raw().Before("*").Register(pluginName+":start_raw", start),
+		cb.Raw().After("*").Register(pluginName+":observe_raw", observe("raw")),
+	)
+}
+
+func start(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func observe(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		repository := "other"
+		if name, ok := db.Get(repositoryKey); ok {
+			repository = name.(string)
+		}
+
+		dbQueryDuration.WithLabelValues(repository, operation).Observe(time.Since(v.(time.Time)).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			dbQueryErrors.WithLabelValues(repository, operation).Inc()
+		}
+	}
+}
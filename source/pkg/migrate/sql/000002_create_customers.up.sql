@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS customers (
+    id            BIGSERIAL PRIMARY KEY,
+    first_name    VARCHAR(100) NOT NULL,
+    last_name     VARCHAR(100) NOT NULL,
+    email         VARCHAR(255) NOT NULL,
+    phone         VARCHAR(20),
+    address       TEXT,
+    city          VARCHAR(100),
+    country       VARCHAR(100) DEFAULT 'Indonesia',
+    date_of_birth DATE,
+    is_active     BOOLEAN      DEFAULT TRUE,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ,
+    deleted_at    TIMESTAMPTZ,
+    CONSTRAINT uni_customers_email UNIQUE (email)
+);
+
+CREATE INDEX IF NOT EXISTS idx_customers_deleted_at ON customers (deleted_at);
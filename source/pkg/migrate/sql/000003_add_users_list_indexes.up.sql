@@ -0,0 +1,3 @@
+-- keyset pagination on GET /api/v1/users orders by (<sort>, id)
+CREATE INDEX IF NOT EXISTS idx_users_name_id ON users (name, id);
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users (created_at, id);
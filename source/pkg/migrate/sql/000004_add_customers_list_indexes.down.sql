@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_customers_created_at_id;
+DROP INDEX IF EXISTS idx_customers_last_name_id;
+DROP INDEX IF EXISTS idx_customers_first_name_id;
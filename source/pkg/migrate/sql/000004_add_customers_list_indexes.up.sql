@@ -0,0 +1,4 @@
+-- keyset pagination on GET /api/v1/customers orders by (<sort>, id)
+CREATE INDEX IF NOT EXISTS idx_customers_first_name_id ON customers (first_name, id);
+CREATE INDEX IF NOT EXISTS idx_customers_last_name_id ON customers (last_name, id);
+CREATE INDEX IF NOT EXISTS idx_customers_created_at_id ON customers (created_at, id);
@@ -0,0 +1,8 @@
+INSERT INTO permissions (name, description) VALUES
+    ('admin:log-level', 'read and change runtime log levels')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'admin:log-level'
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,8 @@
+INSERT INTO permissions (name, description) VALUES
+    ('admin:logs', 'read recent log events of an instance')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'admin:logs'
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,128 @@
+package ratelimit
+
+import (
+	"context"
+	"hash/maphash"
+	"math"
+	"sync"
+	"time"
+)
+
+// Rule is a token bucket: Burst tokens at most, refilled at Requests per Period.
+type Rule struct {
+	Requests int
+	Period   time.Duration
+	Burst    int // bucket size, Requests when 0
+}
+
+func (r Rule) capacity() float64 {
+	if r.Burst > 0 {
+		return float64(r.Burst)
+	}
+	return float64(r.Requests)
+}
+
+// perSecond is the refill rate in tokens per second.
+func (r Rule) perSecond() float64 {
+	return float64(r.Requests) / r.Period.Seconds()
+}
+
+// Result is the outcome of one Take.
+type Result struct {
+	Allowed    bool
+	Limit      int           // bucket capacity
+	Remaining  int           // whole tokens left after this request
+	Reset      time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until the next token, zero when allowed
+}
+
+// Store keeps buckets by key. MemoryStore is per process; a shared store
+// (e.g. Redis) implementing Store makes limits hold across instances.
+type Store interface {
+	Take(ctx context.Context, key string, rule Rule, now time.Time) (Result, error)
+}
+
+const (
+	shardCount   = 32
+	minSweepSize = 4096 // buckets in a shard before the first sweep
+)
+
+// bucket keeps the capacity and rate of the rule it was last taken with, so
+// sweep judges it by its own rule, not by the rule of the caller sweeping.
+type bucket struct {
+	tokens   float64
+	last     time.Time
+	capacity float64
+	rate     float64
+}
+
+type shard struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	sweepAt int // size that triggers the next sweep
+}
+
+// MemoryStore is an in-process Store. Keys are spread over shards, each
+// with its own lock, so unrelated clients do not contend.
+type MemoryStore struct {
+	seed   maphash.Seed
+	shards [shardCount]shard
+}
+
+func NewMemoryStore() *MemoryStore {
+	s := &MemoryStore{seed: maphash.MakeSeed()}
+	for i := range s.shards {
+		s.shards[i].buckets = map[string]*bucket{}
+		s.shards[i].sweepAt = minSweepSize
+	}
+	return s
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	sh := &s.shards[maphash.String(s.seed, key)%shardCount]
+	capacity, rate := rule.capacity(), rule.perSecond()
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	b, ok := sh.buckets[key]
+	if !ok {
+		if len(sh.buckets) >= sh.sweepAt {
+			sh.sweep(now)
+		}
+		b = &bucket{tokens: capacity, last: now}
+		sh.buckets[key] = b
+	}
+	b.capacity, b.rate = capacity, rate
+
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	res := Result{Limit: int(capacity)}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = seconds((1 - b.tokens) / rate)
+	}
+	res.Remaining = int(b.tokens)
+	res.Reset = seconds((capacity - b.tokens) / rate)
+	return res, nil
+}
+
+// sweep drops buckets that have refilled completely; they are
+// indistinguishable from new ones. The next sweep waits until the shard has
+// doubled from what is left, so a flood of active keys costs amortized O(1)
+// per new key instead of a full scan each. Callers hold sh.mu.
+func (sh *shard) sweep(now time.Time) {
+	for key, b := range sh.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*b.rate >= b.capacity {
+			delete(sh.buckets, key)
+		}
+	}
+	sh.sweepAt = max(minSweepSize, 2*len(sh.buckets))
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
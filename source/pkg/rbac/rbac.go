@@ -0,0 +1,112 @@
+package rbac
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+)
+
+// Wildcard grants every permission; auth.Anonymous carries it as a scope.
+const Wildcard = "*"
+
+// Set is the effective permissions of a principal.
+type Set map[string]struct{}
+
+func NewSet(perms ...string) Set {
+	s := make(Set, len(perms))
+	for _, p := range perms {
+		s[p] = struct{}{}
+	}
+	return s
+}
+
+// Has reports whether perm is granted, directly or through Wildcard.
+func (s Set) Has(perm string) bool {
+	if _, ok := s[Wildcard]; ok {
+		return true
+	}
+	_, ok := s[perm]
+	return ok
+}
+
+// Policy maps "METHOD /full/route/path" to the permission the route needs.
+// Routes missing from the policy are denied.
+type Policy map[string]string
+
+// Required returns the permission for method and the gin full path.
+func (p Policy) Required(method, fullPath string) (string, bool) {
+	perm, ok := p[method+" "+fullPath]
+	return perm, ok
+}
+
+// PermissionSource loads the permissions a user holds through their roles.
+type PermissionSource func(ctx context.Context, userID uint) ([]string, error)
+
+type entry struct {
+	perms   Set
+	expires time.Time
+}
+
+// Engine resolves the permissions of a principal. Users (JWT principals
+// whose subject is a user id) get the permissions of their roles, cached for
+// ttl; any other principal, e.g. an api key, is limited to its scopes.
+type Engine struct {
+	source PermissionSource
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	users map[uint]entry
+}
+
+func NewEngine(source PermissionSource, ttl time.Duration) *Engine {
+	return &Engine{source: source, ttl: ttl, users: map[uint]entry{}}
+}
+
+func (e *Engine) Permissions(ctx context.Context, p *auth.Principal) (Set, error) {
+	userID, ok := p.UserID()
+	if !ok {
+		return NewSet(p.Scopes...), nil
+	}
+
+	now := time.Now()
+	e.mu.Lock()
+	cached, ok := e.users[userID]
+	e.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.perms, nil
+	}
+
+	perms, err := e.source(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	set := NewSet(perms...)
+
+	e.mu.Lock()
+	e.users[userID] = entry{perms: set, expires: now.Add(e.ttl)}
+	e.mu.Unlock()
+	return set, nil
+}
+
+// Forget drops the cached permissions of userID, e.g. after a role change.
+func (e *Engine) Forget(userID uint) {
+	e.mu.Lock()
+	delete(e.users, userID)
+	e.mu.Unlock()
+}
+
+type permissionsKey struct{}
+
+// WithPermissions stores the resolved permissions for handlers, see Can.
+func WithPermissions(ctx context.Context, perms Set) context.Context {
+	return context.WithValue(ctx, permissionsKey{}, perms)
+}
+
+// Can reports whether the request's principal holds perm. It is false when
+// the request did not pass the Authorize middleware.
+func Can(ctx context.Context, perm string) bool {
+	perms, _ := ctx.Value(permissionsKey{}).(Set)
+	return perms.Has(perm)
+}
@@ -0,0 +1,215 @@
+// Package redact masks personal data and secrets before they reach logs or
+// responses. Struct fields are marked with a redact tag:
+//
+//	Email string `json:"email" redact:"pii"`    // j***@example.com
+//	Token string `json:"token" redact:"secret"` // [REDACTED]
+package redact
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	masked   = "***"
+	Redacted = "[REDACTED]"
+)
+
+// Mask hides a personal value, keeping the first character and, for email
+// addresses, the domain.
+func Mask(s string) string {
+	if s == "" {
+		return ""
+	}
+	local, domain, isEmail := strings.Cut(s, "@")
+	first := []rune(local)
+	if len(first) == 0 {
+		return masked
+	}
+	if isEmail {
+		return string(first[0]) + masked + "@" + domain
+	}
+	return string(first[0]) + masked
+}
+
+// Value returns v as JSON-ready maps and slices, keyed like encoding/json,
+// with tagged fields masked. Zero values are kept so omitempty still applies.
+func Value(v any) any {
+	return value(reflect.ValueOf(v))
+}
+
+// Object logs v with its tagged fields masked:
+//
+//	logger.Info(ctx).Object("customer", redact.Object(c)).Msg("customer created")
+func Object(v any) zerolog.LogObjectMarshaler {
+	return object{v}
+}
+
+type object struct{ v any }
+
+func (o object) MarshalZerologObject(e *zerolog.Event) {
+	if m, ok := Value(o.v).(map[string]any); ok {
+		e.Fields(m)
+	}
+}
+
+var (
+	jsonMarshaler = reflect.TypeFor[json.Marshaler]()
+	textMarshaler = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+func value(rv reflect.Value) any {
+	if !rv.IsValid() {
+		return nil
+	}
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return value(rv.Elem())
+	case reflect.Struct:
+		if leaf(rv.Type()) {
+			return rv.Interface()
+		}
+		out := map[string]any{}
+		structFields(rv, out)
+		return out
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() || !tagged(rv.Type().Elem()) {
+			return rv.Interface()
+		}
+		out := make([]any, rv.Len())
+		for i := range out {
+			out[i] = value(rv.Index(i))
+		}
+		return out
+	case reflect.Map:
+		if rv.IsNil() || !tagged(rv.Type().Elem()) {
+			return rv.Interface()
+		}
+		out := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			out[keyString(iter.Key())] = value(iter.Value())
+		}
+		return out
+	default:
+		return rv.Interface()
+	}
+}
+
+func structFields(rv reflect.Value, out map[string]any) {
+	t := rv.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		fv := rv.Field(i)
+		if f.Anonymous && name == "" && fv.Kind() == reflect.Struct && !leaf(fv.Type()) {
+			structFields(fv, out) // promoted like encoding/json, e.g. gorm.Model
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if strings.Contains(opts, "omitempty") && fv.IsZero() {
+			continue
+		}
+
+		switch f.Tag.Get("redact") {
+		case "secret":
+			if !fv.IsZero() {
+				out[name] = Redacted
+				continue
+			}
+		case "pii":
+			if !fv.IsZero() {
+				out[name] = maskValue(fv)
+				continue
+			}
+		}
+		out[name] = value(fv)
+	}
+}
+
+func maskValue(rv reflect.Value) string {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.String {
+		return Mask(rv.String())
+	}
+	return masked
+}
+
+func keyString(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return k.String()
+	}
+	b, _ := json.Marshal(k.Interface())
+	return strings.Trim(string(b), `"`)
+}
+
+// leaf reports whether t renders itself, like time.Time.
+func leaf(t reflect.Type) bool {
+	return t.Implements(jsonMarshaler) || t.Implements(textMarshaler) ||
+		reflect.PointerTo(t).Implements(jsonMarshaler) || reflect.PointerTo(t).Implements(textMarshaler)
+}
+
+var taggedTypes sync.Map // reflect.Type -> bool
+
+// tagged reports whether values of t can hold a redact-tagged field.
+func tagged(t reflect.Type) bool {
+	return taggedIn(t, map[reflect.Type]bool{})
+}
+
+// taggedIn walks t with the types being inspected on this call in visiting.
+// A type met again is a cycle and counts as untagged, so only results that do
+// not lean on that guess are cached: every true, and the outermost answer.
+func taggedIn(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if v, ok := taggedTypes.Load(t); ok {
+		return v.(bool)
+	}
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+
+	var has bool
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		has = taggedIn(t.Elem(), visiting)
+	case reflect.Interface:
+		has = true // decided by the dynamic value
+	case reflect.Struct:
+		if leaf(t) {
+			break
+		}
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if f.IsExported() && (f.Tag.Get("redact") != "" || taggedIn(f.Type, visiting)) {
+				has = true
+				break
+			}
+		}
+	}
+	delete(visiting, t)
+	if has || len(visiting) == 0 {
+		taggedTypes.Store(t, has)
+	}
+	return has
+}
@@ -0,0 +1,85 @@
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultPatterns are scrubbed when log.redact.patterns is not set. Groups
+// named pre and post are kept around the replacement; the phone pattern
+// uses them to match only digits standing alone, not runs inside ids such
+// as "01234567-8901-4abc-…".
+var DefaultPatterns = map[string]string{
+	"email": `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+	"phone": `(?P<pre>^|[^-\w])(?:\+\d{1,3}[ -]?|0)\d{2,4}[ -]?\d{3,4}[ -]?\d{3,4}(?P<post>$|[^-\w])`,
+}
+
+// Scrubber replaces every match of its patterns with [name].
+type Scrubber struct {
+	rules []rule
+}
+
+type rule struct {
+	re   *regexp.Regexp
+	repl []byte
+}
+
+// NewScrubber compiles patterns, a regular expression per name.
+func NewScrubber(patterns map[string]string) (*Scrubber, error) {
+	names := make([]string, 0, len(patterns))
+	for name := range patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	s := &Scrubber{}
+	for _, name := range names {
+		re, err := regexp.Compile(patterns[name])
+		if err != nil {
+			return nil, fmt.Errorf("pattern %s: %w", name, err)
+		}
+		repl := "[" + strings.ReplaceAll(name, "$", "$$") + "]"
+		if re.SubexpIndex("pre") >= 0 {
+			repl = "${pre}" + repl
+		}
+		if re.SubexpIndex("post") >= 0 {
+			repl += "${post}"
+		}
+		s.rules = append(s.rules, rule{re: re, repl: []byte(repl)})
+	}
+	return s, nil
+}
+
+// Scrub returns p with the matches replaced.
+func (s *Scrubber) Scrub(p []byte) []byte {
+	for _, r := range s.rules {
+		p = r.re.ReplaceAll(p, r.repl)
+	}
+	return p
+}
+
+// Writer scrubs every log line before it reaches w. It sees the encoded
+// event, so messages, errors and SQL are all covered.
+func Writer(w zerolog.LevelWriter, s *Scrubber) zerolog.LevelWriter {
+	return &writer{w: w, s: s}
+}
+
+type writer struct {
+	w zerolog.LevelWriter
+	s *Scrubber
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (w *writer) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if _, err := w.w.WriteLevel(level, w.s.Scrub(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
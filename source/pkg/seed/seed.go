@@ -0,0 +1,114 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	customermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/customer_model"
+	usermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/user_model"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"gorm.io/gorm"
+)
+
+var (
+	firstNames       = []string{"John", "Jane", "Alex", "Sarah", "Mike", "Emma", "David", "Lisa", "Chris", "Anna", "Tom", "Maria", "James", "Linda", "Robert", "Patricia", "Michael", "Jennifer", "William", "Elizabeth"}
+	userLastNames    = []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Rodriguez", "Martinez", "Hernandez", "Lopez", "Gonzalez", "Wilson", "Anderson", "Thomas", "Taylor", "Moore", "Jackson", "Martin"}
+	userDomains      = []string{"gmail.com", "yahoo.com", "outlook.com", "example.com", "test.com", "company.com"}
+	customerLastName = []string{"Wijaya", "Santoso", "Kurniawan", "Sari", "Pratama", "Utomo", "Handayani", "Susanto", "Maharani", "Gunawan", "Fitria", "Permana", "Rahayu", "Nugroho", "Safitri", "Hidayat", "Wulandari", "Setiawan", "Anggraini", "Putra"}
+	cities           = []string{"Jakarta", "Surabaya", "Bandung", "Medan", "Semarang", "Makassar", "Palembang", "Tangerang", "Depok", "Bekasi", "Solo", "Batam", "Pekanbaru", "Bandar Lampung", "Malang", "Yogyakarta", "Bogor", "Denpasar", "Samarinda", "Balikpapan"}
+	customerDomains  = []string{"gmail.com", "yahoo.com", "outlook.com", "company.id", "email.com"}
+	addresses        = []string{"Jl. Sudirman", "Jl. Thamrin", "Jl. Gatot Subroto", "Jl. Kuningan", "Jl. Senayan", "Jl. Kemang", "Jl. Pondok Indah", "Jl. Kelapa Gading", "Jl. Pluit", "Jl. PIK"}
+)
+
+func pick(list []string) string { return list[rand.Intn(len(list))] }
+
+// Users inserts n random users, skipping emails that already exist.
+// Returns how many rows were created.
+func Users(ctx context.Context, database *gorm.DB, n int) int {
+	logger.Info().Int("count", n).Msg("Generating random users...")
+
+	successCount := 0
+	for i := 1; i <= n; i++ {
+		firstName, lastName := pick(firstNames), pick(userLastNames)
+		user := usermodel.User{
+			Name: fmt.Sprintf("%s %s", firstName, lastName),
+			Email: fmt.Sprintf("%s.%s%d@%s",
+				firstName,
+				lastName,
+				rand.Intn(999)+1, // Random number 1-999
+				pick(userDomains)),
+		}
+
+		var existingUser usermodel.User
+		if err := database.WithContext(ctx).Where("email = ?", user.Email).First(&existingUser).Error; err == nil {
+			logger.Info().Str("email", user.Email).Msg("Sample user already exists")
+			continue
+		}
+		if err := database.WithContext(ctx).Create(&user).Error; err != nil {
+			logger.Error().Err(err).Str("email", user.Email).Msg("Failed to create sample user")
+			continue
+		}
+		successCount++
+		logger.Info().Int("index", i).Str("name", user.Name).Str("email", user.Email).Msg("Sample user created")
+	}
+
+	logger.Info().Int("total_created", successCount).Int("total_attempted", n).Msg("User seeding completed!")
+	return successCount
+}
+
+// Customers inserts n random Indonesian customers, skipping emails that already exist.
+// Returns how many rows were created.
+func Customers(ctx context.Context, database *gorm.DB, n int) int {
+	logger.Info().Int("count", n).Msg("Generating sample customers...")
+
+	successCount := 0
+	for i := 1; i <= n; i++ {
+		firstName, lastName := pick(firstNames), pick(customerLastName)
+
+		// Generate random date of birth (age 18-65)
+		minAge := 18 * 365 * 24 * time.Hour
+		maxAge := 65 * 365 * 24 * time.Hour
+		randomAge := minAge + time.Duration(rand.Int63n(int64(maxAge-minAge)))
+		dateOfBirth := time.Now().Add(-randomAge)
+
+		customer := customermodel.Customer{
+			FirstName: firstName,
+			LastName:  lastName,
+			Email: fmt.Sprintf("%s.%s%d@%s",
+				firstName,
+				lastName,
+				rand.Intn(999)+1,
+				pick(customerDomains)),
+			Phone:       fmt.Sprintf("+628%d%d", rand.Intn(9)+1, rand.Intn(90000000)+10000000),
+			Address:     fmt.Sprintf("%s No. %d", pick(addresses), rand.Intn(100)+1),
+			City:        pick(cities),
+			Country:     "Indonesia",
+			DateOfBirth: &dateOfBirth,
+			IsActive:    rand.Float32() > 0.1, // 90% active
+		}
+
+		var existingCustomer customermodel.Customer
+		if err := database.WithContext(ctx).Where("email = ?", customer.Email).First(&existingCustomer).Error; err == nil {
+			logger.Info().Str("email", customer.Email).Msg("Sample customer already exists")
+			continue
+		}
+		if err := database.WithContext(ctx).Create(&customer).Error; err != nil {
+			logger.Error().Err(err).Str("email", customer.Email).Msg("Failed to create sample customer")
+			continue
+		}
+		successCount++
+		logger.Info().
+			Int("index", i).
+			Str("name", customer.FullName()).
+			Str("email", customer.Email).
+			Str("city", customer.City).
+			Str("phone", customer.Phone).
+			Bool("is_active", customer.IsActive).
+			Msg("Sample customer created")
+	}
+
+	logger.Info().Int("total_created", successCount).Int("total_attempted", n).Msg("Customer seeding completed!")
+	return successCount
+}
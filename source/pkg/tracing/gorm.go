@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	pluginName = "tracing"
+	spanKey    = "tracing:span"
+)
+
+// GormPlugin opens a client span per statement, child of the span in the
+// statement's context (see DB.WithContext). db.statement holds the SQL with
+// placeholders, never the bound values.
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string { return pluginName }
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register(pluginName+":start_create", start("create")),
+		cb.Create().After("*").Register(pluginName+":end_create", end),
+		cb.Query().Before("*").Register(pluginName+":start_query", start("query")),
+		cb.Query().After("*").Register(pluginName+":end_query", end),
+		cb.Update().Before("*").Register(pluginName+":start_update", start("update")),
+		cb.Update().After("*").Register(pluginName+":end_update", end),
+		cb.Delete().Before("*").Register(pluginName+":start_delete", start("delete")),
+		cb.Delete().After("*").Register(pluginName+":end_delete", end),
+		cb.Row().Before("*").Register(pluginName+":start_row", start("row")),
+		cb.Row().After("*").Register(pluginName+":end_row", end),
+		cb.Raw().Before("*").Register(pluginName+":start_raw", start("raw")),
+		cb.Raw().After("*").Register(pluginName+":end_raw", end),
+	)
+}
+
+func start(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+			return // no request around it, e.g. migrations
+		}
+		_, span := Tracer().Start(ctx, "db."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "postgresql"),
+				attribute.String("db.operation", operation),
+			),
+		)
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+func end(db *gorm.DB) {
+	v, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span := v.(trace.Span)
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.statement", db.Statement.SQL.String()),
+		attribute.String("db.sql.table", db.Statement.Table),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
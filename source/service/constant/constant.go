@@ -3,4 +3,9 @@ package constant
 const (
 	RequestIDKey    = "request_id"
 	RequestIDHeader = "X-Request-ID"
+
+	ReadYourWritesHeader = "X-Read-Your-Writes"
+
+	PrincipalKey = "principal" // *auth.Principal set by the auth middleware
+	APIKeyHeader = "X-API-Key"
 )
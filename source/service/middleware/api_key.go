@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/i-sub135/go-rest-blueprint/source/service/constant"
+)
+
+// lastUsedEvery throttles last_used_at writes to one per key per interval.
+const lastUsedEvery = time.Minute
+
+var errInvalidAPIKey = apperror.Unauthorized("invalid, expired or revoked api key").WithCode("invalid_api_key")
+
+// APIKeyAuth authenticates requests carrying X-API-Key. Requests without the
+// header pass through untouched so JWTAuth can handle them; mount it first.
+// touch records last_used_at and runs outside the request.
+func APIKeyAuth(keys *auth.APIKeyCache, touch func(ctx context.Context, id uint, at time.Time) error) gin.HandlerFunc {
+	var lastTouch sync.Map // key id -> time.Time
+
+	return func(c *gin.Context) {
+		raw := c.GetHeader(constant.APIKeyHeader)
+		if raw == "" {
+			c.Next()
+			return
+		}
+
+		key, err := keys.Get(c.Request.Context(), auth.HashAPIKey(raw))
+		if err != nil {
+			httpresputils.HttpRespError(c, err)
+			return
+		}
+		now := time.Now()
+		if key == nil || !key.Usable(now) {
+			httpresputils.HttpRespError(c, errInvalidAPIKey)
+			return
+		}
+
+		SetPrincipal(c, &auth.Principal{
+			Subject: key.Owner,
+			Scopes:  key.Scopes,
+			Method:  "api_key",
+			Claims:  map[string]any{"api_key_id": key.ID, "api_key_prefix": key.Prefix},
+		})
+
+		logger.Info(c.Request.Context()).
+			Uint("api_key_id", key.ID).
+			Str("api_key_prefix", key.Prefix).
+			Msg("api key used")
+
+		if prev, ok := lastTouch.Load(key.ID); !ok || now.Sub(prev.(time.Time)) >= lastUsedEvery {
+			lastTouch.Store(key.ID, now)
+			go func(id uint) {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := touch(ctx, id, now); err != nil {
+					logger.Warn().Err(err).Uint("api_key_id", id).Msg("record api key use")
+				}
+			}(key.ID)
+		}
+
+		c.Next()
+	}
+}
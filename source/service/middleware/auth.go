@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/i-sub135/go-rest-blueprint/source/service/constant"
+	"github.com/rs/zerolog"
+)
+
+var (
+	errMissingToken = apperror.Unauthorized("missing bearer token").WithCode("missing_token")
+	errInvalidToken = apperror.Unauthorized("invalid or expired token").WithCode("invalid_token")
+)
+
+// SetPrincipal stores p in the gin context and the request context, and adds
+// the caller to the request logger, see logger.FromContext.
+func SetPrincipal(c *gin.Context, p *auth.Principal) {
+	c.Set(constant.PrincipalKey, p)
+	ctx := logger.With(c.Request.Context(), func(l zerolog.Context) zerolog.Context {
+		return l.Str("subject", p.Subject).Str("auth_method", p.Method)
+	})
+	c.Request = c.Request.WithContext(auth.WithPrincipal(ctx, p))
+}
+
+// JWTAuth authenticates the request from its "Authorization: Bearer" header.
+// A nil verifier means auth is disabled and every request gets auth.Anonymous.
+// Requests already authenticated by an earlier middleware pass through.
+func JWTAuth(v *auth.JWTVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get(constant.PrincipalKey); ok {
+			c.Next()
+			return
+		}
+		if v == nil {
+			SetPrincipal(c, auth.Anonymous())
+			c.Next()
+			return
+		}
+
+		scheme, token, _ := strings.Cut(c.GetHeader("Authorization"), " ")
+		if !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			c.Header("WWW-Authenticate", `Bearer`)
+			httpresputils.HttpRespError(c, errMissingToken)
+			return
+		}
+
+		p, err := v.Verify(strings.TrimSpace(token))
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			httpresputils.HttpRespError(c, errInvalidToken.Wrap(err))
+			return
+		}
+		SetPrincipal(c, p)
+		c.Next()
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/rbac"
+)
+
+var errPermissionDenied = apperror.Forbidden("permission denied").WithCode("permission_denied")
+
+// Authorize checks the permission policy demands for the matched route and
+// stores the principal's permissions for handlers (rbac.Can). Routes without
+// a policy entry are denied. Mount it on a group after the auth middleware.
+func Authorize(engine *rbac.Engine, policy rbac.Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		p, ok := auth.FromContext(ctx)
+		if !ok {
+			httpresputils.HttpRespError(c, errMissingToken)
+			return
+		}
+
+		required, ok := policy.Required(c.Request.Method, c.FullPath())
+		if !ok {
+			logger.Error(ctx).
+				Str("method", c.Request.Method).
+				Msg("no rbac policy for route, denying")
+			httpresputils.HttpRespError(c, errPermissionDenied)
+			return
+		}
+
+		perms, err := engine.Permissions(ctx, p)
+		if err != nil {
+			httpresputils.HttpRespError(c, err)
+			return
+		}
+		c.Request = c.Request.WithContext(rbac.WithPermissions(ctx, perms))
+
+		if !perms.Has(required) {
+			cause := fmt.Errorf("%s %q lacks %s", p.Method, p.Subject, required)
+			httpresputils.HttpRespError(c, errPermissionDenied.Wrap(cause))
+			return
+		}
+		c.Next()
+	}
+}
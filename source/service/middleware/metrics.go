@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/metrics"
+)
+
+// Metrics records http_requests_total, http_request_duration_seconds and
+// http_requests_in_flight. Requests matching no route share the "unmatched"
+// route label so scanners cannot blow up the label set.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		done := metrics.RequestStarted()
+		defer done()
+
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveRequest(c.Request.Method, route, strconv.Itoa(c.Writer.Status()), time.Since(start))
+	}
+}
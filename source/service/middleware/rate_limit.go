@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/ratelimit"
+)
+
+var errRateLimited = apperror.TooMany("rate limit exceeded, retry later").WithCode("rate_limited")
+
+// RateLimit takes a token for the caller from the group's bucket and answers
+// 429 once it is empty. by is "ip", or "identity" to key on the api key or
+// JWT subject and fall back to the client IP; mount it after authentication
+// for the latter. Store failures let the request through.
+func RateLimit(store ratelimit.Store, group string, rule ratelimit.Rule, by string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := group + "|" + rateLimitIdentity(c, by)
+		res, err := store.Take(c.Request.Context(), key, rule, time.Now())
+		if err != nil {
+			logger.Warn(c.Request.Context()).Err(err).
+				Str("group", group).
+				Msg("rate limit store failed, request allowed")
+			c.Next()
+			return
+		}
+
+		h := c.Writer.Header()
+		h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+		h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		h.Set("RateLimit-Reset", ceilSeconds(res.Reset))
+		if !res.Allowed {
+			h.Set("Retry-After", ceilSeconds(res.RetryAfter))
+			httpresputils.HttpRespError(c, errRateLimited)
+			return
+		}
+		c.Next()
+	}
+}
+
+func rateLimitIdentity(c *gin.Context, by string) string {
+	if by != "ip" {
+		if p, ok := auth.FromContext(c.Request.Context()); ok {
+			switch p.Method {
+			case "api_key":
+				return fmt.Sprintf("key:%v", p.Claims["api_key_id"])
+			case "jwt":
+				return "sub:" + p.Subject
+			}
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds renders d as whole delta-seconds, at least 1 when d is positive.
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/db"
+	"github.com/i-sub135/go-rest-blueprint/source/service/constant"
+)
+
+// ReadYourWritesMiddleware pins every query of the request to the primary
+// when the request writes (anything but GET/HEAD/OPTIONS) or the client sends
+// X-Read-Your-Writes: true, e.g. right after a POST it wants to read back.
+func ReadYourWritesMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			if !strings.EqualFold(c.GetHeader(constant.ReadYourWritesHeader), "true") {
+				c.Next()
+				return
+			}
+		}
+		c.Request = c.Request.WithContext(db.WithPrimary(c.Request.Context()))
+		c.Next()
+	}
+}
@@ -0,0 +1,32 @@
+package service
+
+import "github.com/i-sub135/go-rest-blueprint/source/pkg/rbac"
+
+// policy is the permission each /api/v1 route needs, checked by
+// middleware.Authorize. A route missing here answers 403.
+var policy = rbac.Policy{
+	"GET /api/v1/users":        "users:read:any",
+	"GET /api/v1/users/email":  "users:read:any",
+	"GET /api/v1/users/:id":    "users:read", // own record only, unless users:read:any
+	"POST /api/v1/users":       "users:write",
+	"PUT /api/v1/users/:id":    "users:write",
+	"PATCH /api/v1/users/:id":  "users:write",
+	"DELETE /api/v1/users/:id": "users:write",
+
+	"GET /api/v1/customers":                 "customers:read",
+	"GET /api/v1/customers/:id":             "customers:read",
+	"POST /api/v1/customers":                "customers:write",
+	"PUT /api/v1/customers/:id":             "customers:write",
+	"DELETE /api/v1/customers/:id":          "customers:write",
+	"POST /api/v1/customers/:id/restore":    "customers:write",
+	"POST /api/v1/customers/:id/activate":   "customers:write",
+	"POST /api/v1/customers/:id/deactivate": "customers:write",
+
+	"GET /api/v1/admin/api-keys":             "admin:api-keys",
+	"POST /api/v1/admin/api-keys":            "admin:api-keys",
+	"POST /api/v1/admin/api-keys/:id/rotate": "admin:api-keys",
+	"DELETE /api/v1/admin/api-keys/:id":      "admin:api-keys",
+	"GET /api/v1/admin/log-level":            "admin:log-level",
+	"PUT /api/v1/admin/log-level":            "admin:log-level",
+	"GET /api/v1/admin/logs":                 "admin:logs",
+}
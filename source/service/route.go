@@ -1,37 +1,138 @@
 package service
 
 import (
+	"slices"
+
+	"github.com/i-sub135/go-rest-blueprint/source/feature/private/get_log_level"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/private/issue_api_key"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/private/list_api_keys"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/private/list_logs"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/private/revoke_api_key"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/private/rotate_api_key"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/private/set_log_level"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/public/create_customer"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/public/create_user"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/public/delete_customer"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/public/delete_user"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/public/get_all_customer"
 	"github.com/i-sub135/go-rest-blueprint/source/feature/public/get_all_user"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/public/get_customer_by_id"
 	"github.com/i-sub135/go-rest-blueprint/source/feature/public/get_user_by_id"
 	"github.com/i-sub135/go-rest-blueprint/source/feature/public/get_user_email"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/public/patch_user"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/public/restore_customer"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/public/set_customer_active"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/public/update_customer"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/public/update_user"
 
 	"github.com/gin-gonic/gin"
+	apikeyrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/api_key_repo"
 	customerrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/customer_repo"
+	rbacrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/rbac_repo"
 	userrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/user_repo"
+	"github.com/i-sub135/go-rest-blueprint/source/config"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/ratelimit"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/rbac"
+	"github.com/i-sub135/go-rest-blueprint/source/service/middleware"
 
 	"gorm.io/gorm"
 )
 
 type Routers struct {
-	db *gorm.DB
+	db       *gorm.DB
+	cfg      *config.Config
+	jwtAuthn gin.HandlerFunc
+	limits   ratelimit.Store
 }
 
-func NewRouters(db *gorm.DB) *Routers {
+// NewRouters takes the JWT middleware mounted on every group, see
+// middleware.JWTAuth.
+func NewRouters(db *gorm.DB, cfg *config.Config, jwtAuthn gin.HandlerFunc) *Routers {
 	return &Routers{
-		db: db,
+		db:       db,
+		cfg:      cfg,
+		jwtAuthn: jwtAuthn,
+		limits:   ratelimit.NewMemoryStore(),
 	}
 }
 
 func (r *Routers) MountRouters(routeGroup *gin.RouterGroup) {
 
+	// per client IP, before authentication so bad credentials are limited too
+	routeGroup.Use(r.rateLimit("api")...)
+
+	// authentication: X-API-Key first, then the bearer token
+	apiKeyRepo := apikeyrepo.NewRepo(r.db)
+	apiKeys := auth.NewAPIKeyCache(apiKeyRepo.GetByHash, r.cfg.Auth.APIKey.CacheTTL)
+
+	// authorization: role permissions for users, scopes for everyone else,
+	// checked against policy; it is the only per-route gate
+	rbacRepo := rbacrepo.NewRepo(r.db)
+	authz := middleware.Authorize(rbac.NewEngine(rbacRepo.PermissionsOfUser, r.cfg.Auth.RBAC.CacheTTL), policy)
+
+	// authenticate, limit per identity, then authorize
+	authn := []gin.HandlerFunc{middleware.APIKeyAuth(apiKeys, apiKeyRepo.TouchLastUsed), r.jwtAuthn}
+	guard := func(group string) []gin.HandlerFunc {
+		return slices.Concat(authn, r.rateLimit(group), []gin.HandlerFunc{authz})
+	}
+
 	// endpoint group user
 	userRepo := userrepo.NewUserRepo(r.db)
 	custRepo := customerrepo.NewRepo(r.db)
 	userRoute := routeGroup.Group("/users")
+	userRoute.Use(guard("users")...)
 
-	// userRoute.Use(middleware) uncommand for use middleware
 	userRoute.GET("", get_all_user.NewHandler(userRepo))
 	userRoute.GET("/:id", get_user_by_id.NewHandler(userRepo))
 	userRoute.GET("/email", get_user_email.NewHandler(userRepo, custRepo))
+	userRoute.POST("", create_user.NewHandler(userRepo))
+	userRoute.PUT("/:id", update_user.NewHandler(userRepo))
+	userRoute.PATCH("/:id", patch_user.NewHandler(userRepo))
+	userRoute.DELETE("/:id", delete_user.NewHandler(userRepo))
+
+	// endpoint group customer
+	customerRoute := routeGroup.Group("/customers")
+	customerRoute.Use(guard("customers")...)
+
+	customerRoute.GET("", get_all_customer.NewHandler(custRepo))
+	customerRoute.GET("/:id", get_customer_by_id.NewHandler(custRepo))
+	customerRoute.POST("", create_customer.NewHandler(custRepo))
+	customerRoute.PUT("/:id", update_customer.NewHandler(custRepo))
+	customerRoute.DELETE("/:id", delete_customer.NewHandler(custRepo))
+	customerRoute.POST("/:id/restore", restore_customer.NewHandler(custRepo))
+	customerRoute.POST("/:id/activate", set_customer_active.NewHandler(custRepo, true))
+	customerRoute.POST("/:id/deactivate", set_customer_active.NewHandler(custRepo, false))
 
+	// endpoint group admin
+	adminRoute := routeGroup.Group("/admin")
+	adminRoute.Use(guard("admin")...)
+
+	apiKeyRoute := adminRoute.Group("/api-keys")
+	apiKeyRoute.GET("", list_api_keys.NewHandler(apiKeyRepo))
+	apiKeyRoute.POST("", issue_api_key.NewHandler(apiKeyRepo))
+	apiKeyRoute.POST("/:id/rotate", rotate_api_key.NewHandler(apiKeyRepo, apiKeys))
+	apiKeyRoute.DELETE("/:id", revoke_api_key.NewHandler(apiKeyRepo, apiKeys))
+
+	logLevelRoute := adminRoute.Group("/log-level")
+	logLevelRoute.GET("", get_log_level.NewHandler())
+	logLevelRoute.PUT("", set_log_level.NewHandler())
+
+	adminRoute.GET("/logs", list_logs.NewHandler())
+
+}
+
+// rateLimit returns the limiter of a group from http.rate_limit.groups, none
+// when limiting is disabled or the group has no rule.
+func (r *Routers) rateLimit(group string) []gin.HandlerFunc {
+	cfg := r.cfg.HTTP.RateLimit
+	rule, ok := cfg.Groups[group]
+	if !cfg.Enabled || !ok {
+		return nil
+	}
+	return []gin.HandlerFunc{middleware.RateLimit(r.limits, group, ratelimit.Rule{
+		Requests: rule.Requests,
+		Period:   rule.Period,
+		Burst:    rule.Burst,
+	}, rule.By)}
 }
@@ -0,0 +1,65 @@
+package apperror_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+func TestFrom_StatusMapping(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"record not found", gorm.ErrRecordNotFound, http.StatusNotFound},
+		{"duplicated key", gorm.ErrDuplicatedKey, http.StatusConflict},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, http.StatusConflict},
+		{"foreign key violation", &pgconn.PgError{Code: "23503"}, http.StatusConflict},
+		{"check violation", &pgconn.PgError{Code: "23514"}, http.StatusUnprocessableEntity},
+		{"connection exception", &pgconn.PgError{Code: "08006"}, http.StatusServiceUnavailable},
+		{"deadline", context.DeadlineExceeded, http.StatusServiceUnavailable},
+		{"client gone", fmt.Errorf("query: %w", context.Canceled), apperror.StatusClientClosedRequest},
+		{"wrapped", fmt.Errorf("repo: %w", gorm.ErrRecordNotFound), http.StatusNotFound},
+		{"unknown", errors.New("syntax error at or near SELECT"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		if got := apperror.From(tc.err).Kind.HTTPStatus(); got != tc.status {
+			t.Errorf("%s: status = %d, want %d", tc.name, got, tc.status)
+		}
+	}
+}
+
+func TestFrom_HidesCause(t *testing.T) {
+	cause := errors.New(`pq: relation "users" does not exist`)
+	ae := apperror.From(cause)
+
+	if ae.Message != "internal server error" {
+		t.Errorf("Message = %q, leaks the cause", ae.Message)
+	}
+	if !errors.Is(ae, cause) {
+		t.Error("cause is not kept for logging")
+	}
+}
+
+func TestError_IsMatchesSentinelAfterWrap(t *testing.T) {
+	errTaken := apperror.Conflict("email already registered").WithCode("email_taken")
+	err := fmt.Errorf("create: %w", errTaken.Wrap(gorm.ErrDuplicatedKey))
+
+	if !errors.Is(err, errTaken) {
+		t.Error("wrapped sentinel does not match")
+	}
+	if errors.Is(err, apperror.Conflict("other")) {
+		t.Error("different code matched")
+	}
+	if got := apperror.From(err); got.Code != "email_taken" {
+		t.Errorf("Code = %q, want email_taken", got.Code)
+	}
+}
@@ -0,0 +1,71 @@
+package httpresputils_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	httpresputils "github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/http_resp_utils"
+	"github.com/i-sub135/go-rest-blueprint/source/service/constant"
+)
+
+func serveError(accept string, err error) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/v1/users/42", nil)
+	if accept != "" {
+		c.Request.Header.Set("Accept", accept)
+	}
+	c.Set(constant.RequestIDKey, "req-1")
+	httpresputils.HttpRespError(c, err)
+	return w
+}
+
+func TestHttpRespError_ProblemWhenAccepted(t *testing.T) {
+	w := serveError("application/problem+json, application/json;q=0.9", apperror.NotFound("user not found").WithCode("user_not_found"))
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"type":       "about:blank",
+		"title":      "Not Found",
+		"status":     float64(404),
+		"detail":     "user not found",
+		"instance":   "/api/v1/users/42",
+		"code":       "user_not_found",
+		"request_id": "req-1",
+	}
+	for key, v := range want {
+		if body[key] != v {
+			t.Errorf("%s = %v, want %v", key, body[key], v)
+		}
+	}
+}
+
+func TestHttpRespError_EnvelopeByDefault(t *testing.T) {
+	w := serveError("application/json", apperror.Conflict("email already registered"))
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["status"] != "Conflict" || body["message"] != "email already registered" {
+		t.Errorf("unexpected envelope %v", body)
+	}
+}
@@ -0,0 +1,80 @@
+package paginate_test
+
+import (
+	"encoding/base64"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/i-sub135/go-rest-blueprint/source/common/glob_utils/paginate"
+)
+
+var sortFields = []string{"name", "email", "created_at"}
+
+func newContext(rawQuery string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/users?"+rawQuery, nil)
+	return c
+}
+
+func TestFromQuery_Defaults(t *testing.T) {
+	p, err := paginate.FromQuery(newContext(""), sortFields, "created_at")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if p.Page != 1 || p.PageSize != paginate.DefaultPageSize {
+		t.Errorf("Expected page=1 page_size=%d, got page=%d page_size=%d", paginate.DefaultPageSize, p.Page, p.PageSize)
+	}
+	if p.Sort != "created_at" || p.Desc {
+		t.Errorf("Expected sort=created_at asc, got sort=%s desc=%v", p.Sort, p.Desc)
+	}
+}
+
+func TestFromQuery_ClampsPageSize(t *testing.T) {
+	p, err := paginate.FromQuery(newContext("page_size=1000&sort=name&order=desc"), sortFields, "created_at")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if p.PageSize != paginate.MaxPageSize {
+		t.Errorf("Expected page_size=%d, got %d", paginate.MaxPageSize, p.PageSize)
+	}
+	if p.Sort != "name" || !p.Desc {
+		t.Errorf("Expected sort=name desc, got sort=%s desc=%v", p.Sort, p.Desc)
+	}
+}
+
+func TestFromQuery_RejectsInvalidInput(t *testing.T) {
+	otherSort := base64.RawURLEncoding.EncodeToString([]byte(`{"v":"a","id":1,"s":"email"}`))
+	forged := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+
+	cases := map[string]string{
+		"unknown sort":        "sort=password",
+		"bad order":           "order=sideways",
+		"zero page":           "page=0",
+		"garbage cursor":      "cursor=not-a-cursor",
+		"cursor sort differs": "sort=name&cursor=" + otherSort,
+		"cursor value object": "sort=email&cursor=" + forged(`{"v":{"x":1},"id":1,"s":"email"}`),
+		"cursor value number": "sort=email&cursor=" + forged(`{"v":1,"id":1,"s":"email"}`),
+		"cursor value null":   "sort=name&cursor=" + forged(`{"id":1,"s":"name"}`),
+		"cursor bad time":     "sort=created_at&cursor=" + forged(`{"v":"yesterday","id":1,"s":"created_at"}`),
+	}
+	for name, query := range cases {
+		if _, err := paginate.FromQuery(newContext(query), sortFields, "created_at"); err == nil {
+			t.Errorf("%s: expected error for %q", name, query)
+		}
+	}
+}
+
+func TestFromQuery_AcceptsTypedCursorValues(t *testing.T) {
+	cursors := map[string]string{
+		"sort=email":      `{"v":"a@example.com","id":1,"s":"email"}`,
+		"sort=created_at": `{"v":"2026-10-17T09:45:50.189084951Z","id":1,"s":"created_at"}`,
+	}
+	for query, raw := range cursors {
+		query += "&cursor=" + base64.RawURLEncoding.EncodeToString([]byte(raw))
+		if _, err := paginate.FromQuery(newContext(query), sortFields, "created_at"); err != nil {
+			t.Errorf("%s: %v", query, err)
+		}
+	}
+}
@@ -18,8 +18,8 @@ func TestLoadConfig_BasicFunctionality(t *testing.T) {
 	}
 
 	cfg := config.GetConfig()
-	if cfg.App.Mode != "local" {
-		t.Errorf("Expected default App.Mode=local, got %s", cfg.App.Mode)
+	if cfg.App.Env != "local" {
+		t.Errorf("Expected default App.Env=local, got %s", cfg.App.Env)
 	}
 	if cfg.App.Port != 8080 {
 		t.Errorf("Expected default app.port=8080, got %d", cfg.App.Port)
@@ -40,14 +40,32 @@ func TestLoadConfig_WithFile(t *testing.T) {
 	}
 
 	cfg := config.GetConfig()
-	if cfg.App.Mode != "test" {
-		t.Errorf("Expected App.Mode=test, got %s", cfg.App.Mode)
+	if cfg.App.Env != "test" {
+		t.Errorf("Expected App.Env=test, got %s", cfg.App.Env)
 	}
 	if cfg.App.Port != 9000 {
 		t.Errorf("Expected app.port=9000, got %d", cfg.App.Port)
 	}
 }
 
+func TestLoadConfig_KeepsExplicitZeroPoolSizes(t *testing.T) {
+	config.ResetConfig()
+	clearEnvVars()
+
+	tmpFile := createTempYAML(t, "db:\n  max_idle_conns: 0")
+	if err := config.LoadConfig(tmpFile); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	cfg := config.GetConfig()
+	if cfg.DB.MaxIdleConns != 0 {
+		t.Errorf("Expected explicit db.max_idle_conns=0 kept, got %d", cfg.DB.MaxIdleConns)
+	}
+	if cfg.DB.MaxOpenConns != 25 {
+		t.Errorf("Expected default db.max_open_conns=25, got %d", cfg.DB.MaxOpenConns)
+	}
+}
+
 func createTempYAML(t *testing.T, content string) string {
 	tmpDir := t.TempDir()
 	tmpFile := filepath.Join(tmpDir, "test_config.yaml")
@@ -0,0 +1,82 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/i-sub135/go-rest-blueprint/source/config"
+)
+
+func TestLoad_LayersOverrideInOrder(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	base := write("config.yaml", "app:\n  env: staging\n  port: 9000\nlog:\n  level: info\ndb:\n  max_open_conns: 5\n")
+	staging := write("config.staging.yaml", "app:\n  port: 9001\nlog:\n  level: warn\n")
+	write("config.production.yaml", "app:\n  port: 9999\n")
+	write("config.local.yaml", "app:\n  port: 8000\n") // the local profile, not in effect
+	override := write("config.override.yaml", "log:\n  level: error\n")
+	t.Setenv("DB_MAX_OPEN_CONNS", "8")
+	config.Overrides["db.max_idle_conns"] = "3"
+	t.Cleanup(func() { delete(config.Overrides, "db.max_idle_conns") })
+
+	cfg, err := config.Load(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, tc := range map[string]struct {
+		got    any
+		value  any
+		source string
+	}{
+		"app.env":           {cfg.App.Env, "staging", "file " + base},
+		"app.port":          {cfg.App.Port, 9001, "file " + staging},
+		"log.level":         {cfg.Log.Level, "error", "file " + override},
+		"db.max_open_conns": {cfg.DB.MaxOpenConns, 8, "env DB_MAX_OPEN_CONNS"},
+		"db.max_idle_conns": {cfg.DB.MaxIdleConns, 3, "flag --set"},
+		"http.drain_delay":  {cfg.HTTP.DrainDelay.String(), "5s", "default"},
+	} {
+		if tc.got != tc.value || cfg.Source(key) != tc.source {
+			t.Errorf("%s = %v from %q, want %v from %q", key, tc.got, cfg.Source(key), tc.value, tc.source)
+		}
+	}
+
+	// APP_ENV picks another overlay
+	t.Setenv("APP_ENV", "production")
+	cfg, err = config.Load(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.App.Env != "production" || cfg.App.Port != 9999 {
+		t.Errorf("app.env = %s, app.port = %d, want production and 9999", cfg.App.Env, cfg.App.Port)
+	}
+}
+
+func TestLoad_OverrideAppliesOverTheLocalProfile(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "config.yaml")
+	for name, content := range map[string]string{
+		"config.yaml":          "app:\n  env: local\n  port: 9000\n",
+		"config.local.yaml":    "app:\n  port: 8000\nlog:\n  level: warn\n",
+		"config.override.yaml": "log:\n  level: error\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := config.Load(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.App.Port != 8000 || cfg.Log.Level != "error" {
+		t.Errorf("app.port = %d, log.level = %s, want 8000 from the profile and error from the override", cfg.App.Port, cfg.Log.Level)
+	}
+}
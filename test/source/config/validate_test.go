@@ -0,0 +1,157 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/i-sub135/go-rest-blueprint/source/config"
+)
+
+func TestLoad_RejectsUnknownKeys(t *testing.T) {
+	path := createTempYAML(t, "app:\n  prot: 9000\n")
+
+	_, err := config.Load(path)
+	if err == nil || !strings.Contains(err.Error(), "app.prot (file "+path+"): unknown key, did you mean app.port?") {
+		t.Fatalf("err = %v", err)
+	}
+
+	config.Strict = false
+	t.Cleanup(func() { config.Strict = true })
+	if _, err := config.Load(path); err != nil {
+		t.Errorf("non-strict load: %v", err)
+	}
+}
+
+func TestLoad_EnvKeepsUnderscoredNames(t *testing.T) {
+	t.Setenv("DB_MAX_OPEN_CONNS", "7")
+	t.Setenv("LOG_REDACT_SQL", "false")
+	t.Setenv("LOG_MODULES_GORM", "warn")
+	t.Setenv("HTTP_RATE_LIMIT_GROUPS_USERS_REQUESTS", "5")
+
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DB.MaxOpenConns != 7 || cfg.Log.RedactSQL || cfg.Log.Modules["gorm"] != "warn" {
+		t.Errorf("db.max_open_conns = %d, log.redact_sql = %v, log.modules = %v",
+			cfg.DB.MaxOpenConns, cfg.Log.RedactSQL, cfg.Log.Modules)
+	}
+	if got := cfg.HTTP.RateLimit.Groups["users"].Requests; got != 5 {
+		t.Errorf("http.rate_limit.groups.users.requests = %d, want 5", got)
+	}
+	if src := cfg.Source("db.max_open_conns"); src != "env DB_MAX_OPEN_CONNS" {
+		t.Errorf("source = %q", src)
+	}
+}
+
+func TestValidate_ListsEveryProblemWithSource(t *testing.T) {
+	t.Setenv("APP_PORT", "70000")
+	path := createTempYAML(t, "app:\n  mode: prod\nlog:\n  level: verbose\n  sinks:\n    - type: file\n")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = config.Validate(cfg)
+	if err == nil {
+		t.Fatal("want validation errors")
+	}
+	for _, want := range []string{
+		`app.mode (file ` + path + `): "prod" is not one of debug, release, test`,
+		`app.port (env APP_PORT): must be at most 65535, got 70000`,
+		`log.level (file ` + path + `): "verbose" is not one of`,
+		`log.sinks[0].path (file ` + path + `): required by file sinks`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %q in:\n%v", want, err)
+		}
+	}
+}
+
+func TestValidate_SectionRuleReportsSourceOfItsKeys(t *testing.T) {
+	path := createTempYAML(t, "log:\n  sampling:\n    enabled: true\n    period: 0s\n")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = config.Validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "log.sampling (file "+path+"): burst and period must be positive") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestValidate_ReservesOverrideAsProfile(t *testing.T) {
+	path := createTempYAML(t, "app:\n  env: override\n")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = config.Validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), `app.env (file `+path+`): "override" is reserved`) {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestValidate_ShippedConfig(t *testing.T) {
+	cfg, err := config.Load("../../../config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Validate(cfg); err != nil {
+		t.Errorf("config.yaml: %v", err)
+	}
+}
+
+func TestValidateServe_RequiresJWTKey(t *testing.T) {
+	path := createTempYAML(t, "auth:\n  enabled: true\n  jwt:\n    hmac_secret: \"\"\n")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Validate(cfg); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	err = config.ValidateServe(cfg)
+	if err == nil || !strings.Contains(err.Error(), "auth.jwt (file "+path+"): one of hmac_secret") {
+		t.Errorf("ValidateServe: %v", err)
+	}
+
+	cfg.Auth.JWT.HMACSecret = "dev"
+	if err := config.ValidateServe(cfg); err != nil {
+		t.Errorf("with hmac_secret: %v", err)
+	}
+}
+
+func TestValidate_RefusesCredentialsForAnyOrigin(t *testing.T) {
+	path := createTempYAML(t, "http:\n  security:\n    cors:\n      allowed_origins: [\"*\"]\n      allow_credentials: true\n")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = config.Validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "http.security.cors.allow_credentials (file "+path+"): cannot be true") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestValidate_RefusesDisabledAuthInRelease(t *testing.T) {
+	path := createTempYAML(t, "app:\n  mode: release\nauth:\n  enabled: false\n")
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = config.Validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "auth.enabled (file "+path+"): must be true in release mode") {
+		t.Errorf("err = %v", err)
+	}
+
+	cfg.App.Mode = "debug"
+	if err := config.Validate(cfg); err != nil {
+		t.Errorf("debug mode: %v", err)
+	}
+}
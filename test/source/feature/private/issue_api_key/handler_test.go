@@ -0,0 +1,49 @@
+package issue_api_key_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	apikeyrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/api_key_repo"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/private/issue_api_key"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/rbac"
+)
+
+// issue posts body as a caller holding perms; the checks under test answer
+// before the repository is reached, so it has no database.
+func issue(perms rbac.Set, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/v1/admin/api-keys", func(c *gin.Context) {
+		c.Request = c.Request.WithContext(rbac.WithPermissions(c.Request.Context(), perms))
+	}, issue_api_key.NewHandler(apikeyrepo.NewRepo(nil)))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/api-keys", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIssueAPIKey_RejectsScopesTheCallerLacks(t *testing.T) {
+	caller := rbac.NewSet("admin:api-keys", "users:read")
+
+	w := issue(caller, `{"name": "ci", "owner": "ops", "scopes": ["users:read", "users:delete"]}`)
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "scope_not_held") {
+		t.Errorf("scope not held: %d %s", w.Code, w.Body)
+	}
+
+	w = issue(caller, `{"name": "ci", "owner": "ops", "scopes": ["*"]}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "wildcard_scope") {
+		t.Errorf("wildcard: %d %s", w.Code, w.Body)
+	}
+
+	// even a wildcard holder cannot hand the wildcard on
+	w = issue(rbac.NewSet(rbac.Wildcard), `{"name": "ci", "owner": "ops", "scopes": ["*"]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("wildcard from wildcard holder: %d %s", w.Code, w.Body)
+	}
+}
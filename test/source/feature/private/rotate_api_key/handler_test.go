@@ -0,0 +1,74 @@
+package rotate_api_key_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	apikeymodel "github.com/i-sub135/go-rest-blueprint/source/common/model/api_key_model"
+	apikeyrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/api_key_repo"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/private/rotate_api_key"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/rbac"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// stored opens a dry-run database whose every read yields key, so the
+// handler finds it without a server; writes are built but not sent.
+func stored(t *testing.T, key apikeymodel.APIKey) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.Callback().Query().Replace("gorm:query", func(tx *gorm.DB) {
+		if dest, ok := tx.Statement.Dest.(*apikeymodel.APIKey); ok {
+			*dest = key
+			tx.RowsAffected = 1
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// rotate rotates key 7 as a caller holding perms.
+func rotate(t *testing.T, perms rbac.Set, scopes ...string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	db := stored(t, apikeymodel.APIKey{ID: 7, Name: "ci", Owner: "ops", Scopes: scopes})
+	r := gin.New()
+	r.POST("/api/v1/admin/api-keys/:id/rotate", func(c *gin.Context) {
+		c.Request = c.Request.WithContext(rbac.WithPermissions(c.Request.Context(), perms))
+	}, rotate_api_key.NewHandler(apikeyrepo.NewRepo(db), auth.NewAPIKeyCache(nil, 0)))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/admin/api-keys/7/rotate", nil))
+	return w
+}
+
+func TestRotateAPIKey_RejectsScopesTheCallerLacks(t *testing.T) {
+	caller := rbac.NewSet("admin:api-keys", "users:read")
+
+	w := rotate(t, caller, "users:read", "users:delete")
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "scope_not_held") {
+		t.Errorf("scope not held: %d %s", w.Code, w.Body)
+	}
+
+	w = rotate(t, rbac.NewSet(rbac.Wildcard), "*")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "wildcard_scope") {
+		t.Errorf("wildcard: %d %s", w.Code, w.Body)
+	}
+
+	w = rotate(t, caller, "users:read")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"key":`) {
+		t.Errorf("held scopes: %d %s", w.Code, w.Body)
+	}
+}
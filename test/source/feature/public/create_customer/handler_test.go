@@ -0,0 +1,70 @@
+package create_customer_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	customerrepo "github.com/i-sub135/go-rest-blueprint/source/common/repository/customer_repo"
+	"github.com/i-sub135/go-rest-blueprint/source/feature/public/create_customer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var insertColumns = regexp.MustCompile(`^INSERT INTO "customers" \(([^)]*)\)`)
+
+// inserted posts body and returns the values of the INSERT it produced by
+// column. The database runs in dry-run mode, so nothing is sent.
+func inserted(t *testing.T, body string) (map[string]any, *httptest.ResponseRecorder) {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]any{}
+	err = db.Callback().Create().After("gorm:create").Register("test:capture", func(tx *gorm.DB) {
+		m := insertColumns.FindStringSubmatch(tx.Statement.SQL.String())
+		if m == nil {
+			return
+		}
+		for i, col := range strings.Split(m[1], ",") {
+			values[strings.Trim(col, `" `)] = tx.Statement.Vars[i]
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/v1/customers", create_customer.NewHandler(customerrepo.NewRepo(db)))
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/customers", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	return values, w
+}
+
+func TestCreateCustomer_IsActive(t *testing.T) {
+	for body, want := range map[string]bool{
+		`{"first_name": "James", "last_name": "Martinez", "email": "james@example.com", "is_active": false}`: false,
+		`{"first_name": "James", "last_name": "Martinez", "email": "james@example.com", "is_active": true}`:  true,
+		`{"first_name": "James", "last_name": "Martinez", "email": "james@example.com"}`:                     true,
+	} {
+		values, w := inserted(t, body)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("%s: %d %s", body, w.Code, w.Body)
+		}
+		// a value left out of the INSERT would take the column default
+		if got, ok := values["is_active"]; !ok || got != want {
+			t.Errorf("%s: inserted is_active = %v (present %v), want %v", body, got, ok, want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	apperror "github.com/i-sub135/go-rest-blueprint/source/common/app_error"
+	apikeymodel "github.com/i-sub135/go-rest-blueprint/source/common/model/api_key_model"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(key, prefix) || len(prefix) != 12 {
+		t.Errorf("prefix %q does not start key %q", prefix, key)
+	}
+	if hash != auth.HashAPIKey(key) || strings.Contains(hash, key) {
+		t.Errorf("hash %q does not match key", hash)
+	}
+
+	other, _, _, _ := auth.GenerateAPIKey()
+	if other == key {
+		t.Error("two keys are equal")
+	}
+}
+
+func TestAPIKeyCache(t *testing.T) {
+	calls := 0
+	stored := &apikeymodel.APIKey{ID: 1, KeyHash: "known"}
+	cache := auth.NewAPIKeyCache(func(ctx context.Context, hash string) (*apikeymodel.APIKey, error) {
+		calls++
+		switch hash {
+		case "known":
+			return stored, nil
+		case "broken":
+			return nil, errors.New("connection refused")
+		}
+		return nil, apperror.NotFound("api key not found")
+	}, time.Minute)
+	ctx := context.Background()
+
+	for range 2 {
+		if key, err := cache.Get(ctx, "known"); err != nil || key != stored {
+			t.Fatalf("Get(known) = %v, %v", key, err)
+		}
+		if key, err := cache.Get(ctx, "missing"); err != nil || key != nil {
+			t.Fatalf("Get(missing) = %v, %v", key, err)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("lookups = %d, want 3 (hits cached, misses not)", calls)
+	}
+
+	cache.Invalidate("known")
+	cache.Get(ctx, "known")
+	if calls != 4 {
+		t.Errorf("lookups = %d after Invalidate, want 4", calls)
+	}
+
+	// failures are returned and not cached
+	for range 2 {
+		if _, err := cache.Get(ctx, "broken"); err == nil {
+			t.Error("lookup error swallowed")
+		}
+	}
+	if calls != 6 {
+		t.Errorf("lookups = %d, want 6 (errors not cached)", calls)
+	}
+}
@@ -0,0 +1,119 @@
+package auth_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+)
+
+const secret = "test-secret"
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func claims(extra jwt.MapClaims) jwt.MapClaims {
+	c := jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   "https://issuer.test",
+		"aud":   "go-blueprint",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "users:read users:write",
+	}
+	for k, v := range extra {
+		c[k] = v
+	}
+	return c
+}
+
+func hmacVerifier(t *testing.T) *auth.JWTVerifier {
+	t.Helper()
+	v, err := auth.NewJWTVerifier(auth.JWTOptions{
+		HMACSecret: secret,
+		Issuer:     "https://issuer.test",
+		Audience:   "go-blueprint",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestVerify_HS256(t *testing.T) {
+	p, err := hmacVerifier(t).Verify(sign(t, jwt.SigningMethodHS256, []byte(secret), "", claims(nil)))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if p.Subject != "user-1" || p.Method != "jwt" {
+		t.Errorf("principal = %+v", p)
+	}
+	if !p.HasScope("users:write") || p.HasScope("customers:read") {
+		t.Errorf("scopes = %v", p.Scopes)
+	}
+}
+
+func TestVerify_RejectsBadClaims(t *testing.T) {
+	v := hmacVerifier(t)
+	cases := map[string]string{
+		"expired":       sign(t, jwt.SigningMethodHS256, []byte(secret), "", claims(jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()})),
+		"not yet valid": sign(t, jwt.SigningMethodHS256, []byte(secret), "", claims(jwt.MapClaims{"nbf": time.Now().Add(time.Hour).Unix()})),
+		"no exp":        sign(t, jwt.SigningMethodHS256, []byte(secret), "", claims(jwt.MapClaims{"exp": nil})),
+		"wrong issuer":  sign(t, jwt.SigningMethodHS256, []byte(secret), "", claims(jwt.MapClaims{"iss": "https://evil.test"})),
+		"wrong aud":     sign(t, jwt.SigningMethodHS256, []byte(secret), "", claims(jwt.MapClaims{"aud": "other"})),
+		"wrong secret":  sign(t, jwt.SigningMethodHS256, []byte("other"), "", claims(nil)),
+	}
+	for name, token := range cases {
+		if _, err := v.Verify(token); err == nil {
+			t.Errorf("%s: token accepted", name)
+		}
+	}
+}
+
+func TestVerify_ES256FromJWKS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64 := base64.RawURLEncoding.EncodeToString
+	jwks, _ := json.Marshal(map[string]any{"keys": []map[string]string{{
+		"kty": "EC", "kid": "k1", "use": "sig", "crv": "P-256",
+		"x": b64(key.X.Bytes()), "y": b64(key.Y.Bytes()),
+	}}})
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwks, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := auth.NewJWTVerifier(auth.JWTOptions{JWKSFile: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Verify(sign(t, jwt.SigningMethodES256, key, "k1", claims(nil))); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if _, err := v.Verify(sign(t, jwt.SigningMethodES256, key, "unknown", claims(nil))); err == nil {
+		t.Error("unknown kid accepted")
+	}
+	// an HMAC token must not be accepted when only public keys are configured
+	if _, err := v.Verify(sign(t, jwt.SigningMethodHS256, []byte(secret), "", claims(nil))); err == nil {
+		t.Error("HS256 token accepted without a secret")
+	}
+}
@@ -0,0 +1,63 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/health"
+)
+
+func TestRegistry_CriticalDecidesStatus(t *testing.T) {
+	checks := health.NewRegistry()
+	var cacheDown atomic.Bool
+	cacheDown.Store(true)
+	checks.Register(health.Check{Name: "database", Critical: true, Check: func(context.Context) error { return nil }})
+	checks.Register(health.Check{Name: "cache", Check: func(context.Context) error {
+		if cacheDown.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	}})
+
+	report := checks.Run(context.Background())
+	if report.Status != health.StatusUp {
+		t.Errorf("status = %s, want up with only a non-critical check down", report.Status)
+	}
+	if c := report.Checks[1]; c.Name != "cache" || c.Status != health.StatusDown || c.LastError != "connection refused" {
+		t.Errorf("cache = %+v", c)
+	}
+
+	cacheDown.Store(false)
+	c := checks.Run(context.Background()).Checks[1]
+	if c.Status != health.StatusUp || c.LastError != "connection refused" || c.LastErrorAt == nil {
+		t.Errorf("recovered cache = %+v, want up keeping the last error", c)
+	}
+}
+
+func TestRegistry_TimeoutAndCache(t *testing.T) {
+	checks := health.NewRegistry()
+	var calls atomic.Int32
+	checks.Register(health.Check{
+		Name:     "database",
+		Critical: true,
+		Timeout:  10 * time.Millisecond,
+		CacheTTL: time.Minute,
+		Check: func(ctx context.Context) error {
+			calls.Add(1)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	report := checks.Run(context.Background())
+	if report.Status != health.StatusDown {
+		t.Fatalf("status = %s, want down after the timeout", report.Status)
+	}
+	checks.Run(context.Background())
+	if n := calls.Load(); n != 1 {
+		t.Errorf("check ran %d times, want the cached result reused", n)
+	}
+}
@@ -0,0 +1,128 @@
+package lifecycle_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/lifecycle"
+)
+
+// serve runs lc with one server answering handler and returns its address
+// and the result of Run, once the manager reports ready.
+func serve(t *testing.T, lc *lifecycle.Manager, handler http.Handler) (string, <-chan error) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	lc.AddServer("http", &http.Server{Addr: addr, Handler: handler})
+	done := make(chan error, 1)
+	go func() { done <- lc.Run() }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !lc.Ready() {
+		if time.Now().After(deadline) {
+			t.Fatal("manager never became ready")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return addr, done
+}
+
+// interrupt sends SIGINT to the test process, which Run is listening for.
+func interrupt(t *testing.T) {
+	t.Helper()
+	if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManager_ShutdownOrder(t *testing.T) {
+	lc := lifecycle.New(time.Second, 0)
+	if lc.Ready() || lc.Draining() {
+		t.Fatalf("before Run: ready %v, draining %v", lc.Ready(), lc.Draining())
+	}
+
+	var ran []string
+	for _, name := range []string{"logger", "database", "tracing"} {
+		lc.OnShutdown(name, func(ctx context.Context) error {
+			if lc.Ready() || !lc.Draining() {
+				t.Errorf("hook %s: ready %v, draining %v", name, lc.Ready(), lc.Draining())
+			}
+			ran = append(ran, name)
+			return nil
+		})
+	}
+
+	_, done := serve(t, lc, http.NotFoundHandler())
+	if lc.Draining() {
+		t.Error("draining while serving")
+	}
+	interrupt(t)
+	if err := <-done; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if want := []string{"tracing", "database", "logger"}; !slices.Equal(ran, want) {
+		t.Errorf("hooks ran %v, want %v", ran, want)
+	}
+	if lc.Ready() || !lc.Draining() {
+		t.Errorf("after Run: ready %v, draining %v", lc.Ready(), lc.Draining())
+	}
+}
+
+func TestManager_HooksOutliveSlowDrain(t *testing.T) {
+	lc := lifecycle.New(100*time.Millisecond, 0)
+	var hookErr error
+	lc.OnShutdown("tracing", func(ctx context.Context) error {
+		hookErr = ctx.Err()
+		return nil
+	})
+
+	entered, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	addr, done := serve(t, lc, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-release
+	}))
+	go http.Get("http://" + addr) // held open past the shutdown timeout
+	<-entered
+
+	interrupt(t)
+	if err := <-done; err == nil {
+		t.Error("Run: want the incomplete server shutdown reported")
+	}
+	if hookErr != nil {
+		t.Errorf("hook got a spent context: %v", hookErr)
+	}
+}
+
+func TestManager_AbortRunsHooksWithoutRun(t *testing.T) {
+	lc := lifecycle.New(time.Second, 0)
+	var ran []string
+	for _, name := range []string{"logger", "database", "tracing"} {
+		lc.OnShutdown(name, func(ctx context.Context) error {
+			ran = append(ran, name)
+			return nil
+		})
+	}
+
+	if err := lc.Abort(); err != nil {
+		t.Fatalf("Abort: %v", err)
+	}
+	if want := []string{"tracing", "database", "logger"}; !slices.Equal(ran, want) {
+		t.Errorf("hooks ran %v, want %v", ran, want)
+	}
+	if lc.Ready() || !lc.Draining() {
+		t.Errorf("after Abort: ready %v, draining %v", lc.Ready(), lc.Draining())
+	}
+}
@@ -0,0 +1,43 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+func TestFromContext_CarriesRequestFields(t *testing.T) {
+	var buf bytes.Buffer
+	prev := logger.Log
+	logger.Log = zerolog.New(&buf)
+	t.Cleanup(func() { logger.Log = prev })
+
+	ctx := logger.With(context.Background(), func(l zerolog.Context) zerolog.Context {
+		return l.Str("request_id", "req-1")
+	})
+	ctx = logger.With(ctx, func(l zerolog.Context) zerolog.Context {
+		return l.Str("subject", "42")
+	})
+
+	logger.Info(ctx).Msg("with context")
+	logger.Info().Msg("without context")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("lines = %d, want 2", len(lines))
+	}
+	var withCtx, without map[string]any
+	json.Unmarshal(lines[0], &withCtx)
+	json.Unmarshal(lines[1], &without)
+
+	if withCtx["request_id"] != "req-1" || withCtx["subject"] != "42" {
+		t.Errorf("context event = %v", withCtx)
+	}
+	if _, ok := without["request_id"]; ok {
+		t.Errorf("global event = %v", without)
+	}
+}
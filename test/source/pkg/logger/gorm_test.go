@@ -0,0 +1,82 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func captureLog(t *testing.T, level zerolog.Level) *bytes.Buffer {
+	var buf bytes.Buffer
+	prev, prevLevels := logger.Log, logger.CurrentLevels()
+	logger.Log = zerolog.New(&buf)
+	logger.SetLevels(logger.Levels{Level: level}, 0)
+	t.Cleanup(func() {
+		logger.Log = prev
+		logger.SetLevels(prevLevels, 0)
+	})
+	return &buf
+}
+
+func lastEvent(t *testing.T, buf *bytes.Buffer) map[string]any {
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	var ev map[string]any
+	if err := json.Unmarshal(lines[len(lines)-1], &ev); err != nil {
+		t.Fatalf("no event logged: %q", buf.String())
+	}
+	return ev
+}
+
+func TestGormLogger_LevelsFollowLog(t *testing.T) {
+	buf := captureLog(t, zerolog.InfoLevel)
+	l := logger.NewGormLogger(100*time.Millisecond, true)
+	ctx := context.Background()
+	sql := func() (string, int64) { return "SELECT * FROM users WHERE id = $1", 1 }
+
+	// fast statements are debug, dropped at info
+	l.Trace(ctx, time.Now(), sql, nil)
+	l.Trace(ctx, time.Now(), sql, gorm.ErrRecordNotFound)
+	if buf.Len() != 0 {
+		t.Fatalf("logged at info: %s", buf.String())
+	}
+
+	l.Trace(ctx, time.Now().Add(-time.Second), sql, nil)
+	if ev := lastEvent(t, buf); ev["level"] != "warn" || ev["message"] != "slow sql" || ev["rows"] != float64(1) {
+		t.Errorf("slow query event = %v", ev)
+	}
+
+	l.Trace(ctx, time.Now(), sql, errors.New("boom"))
+	if ev := lastEvent(t, buf); ev["level"] != "error" || ev["error"] != "boom" {
+		t.Errorf("failed query event = %v", ev)
+	}
+}
+
+func TestGormLogger_DebugModeLogsEveryStatement(t *testing.T) {
+	buf := captureLog(t, zerolog.InfoLevel)
+	l := logger.NewGormLogger(0, true).LogMode(gormlogger.Info) // what db.Debug() does
+	sql := func() (string, int64) { return "SELECT * FROM users WHERE id = $1", 1 }
+
+	l.Trace(context.Background(), time.Now(), sql, nil)
+	if ev := lastEvent(t, buf); ev["message"] != "sql" || ev["module"] != "gorm" {
+		t.Errorf("debug mode event = %v", ev)
+	}
+}
+
+func TestGormLogger_ParamsFilter(t *testing.T) {
+	_, params := logger.NewGormLogger(0, true).ParamsFilter(context.Background(), "SELECT $1", "secret")
+	if params != nil {
+		t.Errorf("redacting logger kept params %v", params)
+	}
+	_, params = logger.NewGormLogger(0, false).ParamsFilter(context.Background(), "SELECT $1", "secret")
+	if len(params) != 1 {
+		t.Errorf("params = %v, want them kept", params)
+	}
+}
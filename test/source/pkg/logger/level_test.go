@@ -0,0 +1,54 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+func TestSetLevels_ModuleOverride(t *testing.T) {
+	buf := captureLog(t, zerolog.InfoLevel)
+	logger.SetLevels(logger.Levels{
+		Level:   zerolog.InfoLevel,
+		Modules: map[string]zerolog.Level{"gorm": zerolog.DebugLevel},
+	}, 0)
+	ctx := context.Background()
+
+	logger.Debug(ctx).Msg("dropped")
+	gormLog := logger.Module(ctx, "gorm")
+	gormLog.Debug().Msg("kept")
+
+	if n := bytes.Count(buf.Bytes(), []byte("\n")); n != 1 {
+		t.Fatalf("events = %d, want only the gorm debug one: %s", n, buf.String())
+	}
+	if ev := lastEvent(t, buf); ev["message"] != "kept" || ev["module"] != "gorm" {
+		t.Errorf("event = %v", ev)
+	}
+}
+
+func TestSetLevels_TTLReverts(t *testing.T) {
+	captureLog(t, zerolog.InfoLevel)
+
+	logger.SetLevels(logger.Levels{Level: zerolog.DebugLevel}, 20*time.Millisecond)
+	if l := logger.CurrentLevels(); l.Level != zerolog.DebugLevel || l.RevertAt == nil {
+		t.Fatalf("levels = %+v, want temporary debug", l)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if l := logger.CurrentLevels(); l.Level != zerolog.InfoLevel || l.RevertAt != nil {
+		t.Errorf("levels = %+v, want info again", l)
+	}
+}
+
+func TestParseLevels(t *testing.T) {
+	if _, err := logger.ParseLevels("info", map[string]string{"gorm": "loud"}); err == nil {
+		t.Error("invalid module level accepted")
+	}
+	if _, err := logger.ParseLevels("", nil); err == nil {
+		t.Error("empty level accepted")
+	}
+}
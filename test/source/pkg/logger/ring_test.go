@@ -0,0 +1,41 @@
+package logger_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+func TestRing_KeepsNewestEntries(t *testing.T) {
+	ring := logger.NewRing(3)
+	l := zerolog.New(ring)
+	for i := range 5 {
+		l.Info().Int("n", i).Msg("")
+	}
+	l.Warn().Int("n", 5).Msg("")
+
+	nums := func(entries []json.RawMessage) string {
+		var out []int
+		for _, e := range entries {
+			var ev struct{ N int }
+			if err := json.Unmarshal(e, &ev); err != nil {
+				t.Fatalf("entry %q: %v", e, err)
+			}
+			out = append(out, ev.N)
+		}
+		return fmt.Sprint(out)
+	}
+
+	if got := nums(ring.Entries(zerolog.TraceLevel, 10)); got != "[3 4 5]" {
+		t.Errorf("all entries = %s, want [3 4 5]", got)
+	}
+	if got := nums(ring.Entries(zerolog.TraceLevel, 2)); got != "[4 5]" {
+		t.Errorf("limited entries = %s, want [4 5]", got)
+	}
+	if got := nums(ring.Entries(zerolog.WarnLevel, 10)); got != "[5]" {
+		t.Errorf("warn entries = %s, want [5]", got)
+	}
+}
@@ -0,0 +1,17 @@
+package migrate_test
+
+import (
+	"testing"
+
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/migrate"
+)
+
+func TestNew_LoadsEmbeddedMigrations(t *testing.T) {
+	m, err := migrate.New(nil)
+	if err != nil {
+		t.Fatalf("Expected embedded migrations to load, got %v", err)
+	}
+	if m.Latest() < 2 {
+		t.Errorf("Expected at least 2 migrations, got latest version %d", m.Latest())
+	}
+}
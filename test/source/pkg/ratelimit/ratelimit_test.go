@@ -0,0 +1,96 @@
+package ratelimit_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/ratelimit"
+)
+
+func TestMemoryStore_TokenBucket(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	rule := ratelimit.Rule{Requests: 60, Period: time.Minute, Burst: 3}
+	ctx := context.Background()
+	now := time.Now()
+
+	for i := range 3 {
+		res, err := store.Take(ctx, "a", rule, now)
+		if err != nil || !res.Allowed {
+			t.Fatalf("take %d = %+v, %v", i, res, err)
+		}
+		if res.Limit != 3 || res.Remaining != 2-i {
+			t.Errorf("take %d: limit %d remaining %d", i, res.Limit, res.Remaining)
+		}
+	}
+
+	res, _ := store.Take(ctx, "a", rule, now)
+	if res.Allowed || res.RetryAfter != time.Second || res.Reset != 3*time.Second {
+		t.Errorf("empty bucket = %+v", res)
+	}
+
+	// other keys have their own bucket
+	if res, _ := store.Take(ctx, "b", rule, now); !res.Allowed {
+		t.Error("unrelated key limited")
+	}
+
+	// one token per second refills
+	if res, _ := store.Take(ctx, "a", rule, now.Add(time.Second)); !res.Allowed || res.Remaining != 0 {
+		t.Errorf("after refill = %+v", res)
+	}
+	if res, _ := store.Take(ctx, "a", rule, now.Add(time.Hour)); res.Remaining != 2 {
+		t.Errorf("bucket exceeds burst: %+v", res)
+	}
+}
+
+func TestMemoryStore_BurstDefaultsToRequests(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	rule := ratelimit.Rule{Requests: 5, Period: time.Second}
+
+	res, _ := store.Take(context.Background(), "a", rule, time.Now())
+	if res.Limit != 5 || res.Remaining != 4 {
+		t.Errorf("res = %+v", res)
+	}
+}
+
+func TestMemoryStore_SweepKeepsBucketsOfSlowerRules(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	slow := ratelimit.Rule{Requests: 1, Period: time.Hour}
+	fast := ratelimit.Rule{Requests: 1000, Period: time.Second, Burst: 1}
+	ctx := context.Background()
+	now := time.Now()
+
+	store.Take(ctx, "customers:alice", slow, now)
+
+	// enough keys of a fast rule to fill and sweep every shard; each is full
+	// again a millisecond later, so the sweeps keep the shards small
+	for i := range 200_000 {
+		store.Take(ctx, fmt.Sprintf("api:%d", i), fast, now.Add(time.Duration(i)*time.Millisecond))
+	}
+
+	later := now.Add(200 * time.Second)
+	if res, _ := store.Take(ctx, "customers:alice", slow, later); res.Allowed {
+		t.Errorf("slow bucket reset by a sweep for a faster rule: %+v", res)
+	}
+}
+
+func TestMemoryStore_FloodOfActiveKeysStaysFast(t *testing.T) {
+	if testing.Short() {
+		t.Skip("inserts 300k keys")
+	}
+	store := ratelimit.NewMemoryStore()
+	rule := ratelimit.Rule{Requests: 1, Period: time.Hour}
+	ctx := context.Background()
+	now := time.Now()
+
+	// no bucket ever refills, so a sweep per new key would scan the whole
+	// shard each time: minutes instead of well under a second
+	start := time.Now()
+	for i := range 300_000 {
+		store.Take(ctx, fmt.Sprintf("ip:%d", i), rule, now)
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Second {
+		t.Errorf("300k distinct keys took %v", elapsed)
+	}
+}
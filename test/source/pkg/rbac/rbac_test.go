@@ -0,0 +1,80 @@
+package rbac_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/auth"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/rbac"
+)
+
+func TestEngine_UserPermissionsFromRoles(t *testing.T) {
+	calls := 0
+	engine := rbac.NewEngine(func(ctx context.Context, userID uint) ([]string, error) {
+		calls++
+		if userID == 7 {
+			return []string{"users:read", "customers:read"}, nil
+		}
+		return nil, nil
+	}, time.Minute)
+	ctx := context.Background()
+
+	// scopes on a user token do not add permissions, roles do
+	user := &auth.Principal{Subject: "7", Method: "jwt", Scopes: []string{"users:write"}}
+	for range 2 {
+		perms, err := engine.Permissions(ctx, user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !perms.Has("users:read") || perms.Has("users:write") {
+			t.Errorf("perms = %v", perms)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("source calls = %d, want 1 (cached)", calls)
+	}
+
+	engine.Forget(7)
+	engine.Permissions(ctx, user)
+	if calls != 2 {
+		t.Errorf("source calls = %d after Forget, want 2", calls)
+	}
+}
+
+func TestEngine_NonUsersUseScopes(t *testing.T) {
+	engine := rbac.NewEngine(func(ctx context.Context, userID uint) ([]string, error) {
+		t.Fatal("source called for a non-user principal")
+		return nil, nil
+	}, time.Minute)
+
+	key := &auth.Principal{Subject: "billing-service", Method: "api_key", Scopes: []string{"customers:read"}}
+	perms, _ := engine.Permissions(context.Background(), key)
+	if !perms.Has("customers:read") || perms.Has("customers:write") {
+		t.Errorf("api key perms = %v", perms)
+	}
+
+	perms, _ = engine.Permissions(context.Background(), auth.Anonymous())
+	if !perms.Has("admin:api-keys") {
+		t.Error("anonymous wildcard not honoured")
+	}
+}
+
+func TestPolicyAndCan(t *testing.T) {
+	policy := rbac.Policy{"GET /api/v1/users/:id": "users:read"}
+	if perm, ok := policy.Required("GET", "/api/v1/users/:id"); !ok || perm != "users:read" {
+		t.Errorf("Required = %q, %v", perm, ok)
+	}
+	if _, ok := policy.Required("DELETE", "/api/v1/users/:id"); ok {
+		t.Error("unlisted route has a policy")
+	}
+
+	ctx := context.Background()
+	if rbac.Can(ctx, "users:read") {
+		t.Error("Can is true without resolved permissions")
+	}
+	ctx = rbac.WithPermissions(ctx, rbac.NewSet("users:read"))
+	if !rbac.Can(ctx, "users:read") || rbac.Can(ctx, "users:read:any") {
+		t.Error("Can does not follow the stored set")
+	}
+}
@@ -0,0 +1,155 @@
+package redact_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	customermodel "github.com/i-sub135/go-rest-blueprint/source/common/model/customer_model"
+	"github.com/i-sub135/go-rest-blueprint/source/pkg/redact"
+	"github.com/rs/zerolog"
+)
+
+func TestValue_MasksTaggedFields(t *testing.T) {
+	dob := time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC)
+	customers := []customermodel.Customer{
+		{ID: 1, FirstName: "James", Email: "james.martinez@outlook.com", Phone: "081234567890", DateOfBirth: &dob, City: "Bandung"},
+		{ID: 2, FirstName: "James", Email: "j@x.io"},
+	}
+
+	b, err := json.Marshal(redact.Value(&customers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []map[string]any
+	json.Unmarshal(b, &got)
+
+	first := got[0]
+	if first["email"] != "j***@outlook.com" || first["phone"] != "0***" || first["date_of_birth"] != "***" {
+		t.Errorf("pii not masked: %v", first)
+	}
+	if first["first_name"] != "James" || first["city"] != "Bandung" || first["id"] != float64(1) {
+		t.Errorf("untagged fields changed: %v", first)
+	}
+	if _, ok := got[1]["address"]; ok {
+		t.Errorf("empty omitempty field rendered: %v", got[1])
+	}
+}
+
+func TestObject_MasksTaggedFieldsInLogs(t *testing.T) {
+	dob := time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC)
+	c := customermodel.Customer{
+		ID: 7, FirstName: "James", Email: "james.martinez@outlook.com",
+		Phone: "081234567890", Address: "Jl. Merdeka 10", DateOfBirth: &dob, City: "Bandung",
+	}
+
+	var buf bytes.Buffer
+	l := zerolog.New(&buf)
+	l.Info().Object("customer", redact.Object(c)).Msg("customer created")
+
+	var ev struct {
+		Customer map[string]any `json:"customer"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &ev); err != nil {
+		t.Fatalf("log line is not JSON: %q", buf.String())
+	}
+	got := ev.Customer
+	if got["phone"] != "0***" || got["address"] != "J***" || got["date_of_birth"] != "***" || got["email"] != "j***@outlook.com" {
+		t.Errorf("pii not masked: %v", got)
+	}
+	if got["first_name"] != "James" || got["city"] != "Bandung" || got["id"] != float64(7) {
+		t.Errorf("untagged fields changed: %v", got)
+	}
+}
+
+func TestValue_ConcurrentFirstUseOfAType(t *testing.T) {
+	for n := range 20 {
+		// a type nobody has inspected yet, with a large untagged field first so
+		// the goroutines overlap while it is being inspected
+		typ := reflect.StructOf([]reflect.StructField{
+			{Name: "Pad", Type: wideType(n, 2)},
+			{Name: "Email", Type: reflect.TypeFor[string](), Tag: `json:"email" redact:"pii"`},
+		})
+		rows := reflect.MakeSlice(reflect.SliceOf(typ), 1, 1)
+		rows.Index(0).Field(1).SetString("james.martinez@outlook.com")
+
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				b, _ := json.Marshal(redact.Value(rows.Interface()))
+				if bytes.Contains(b, []byte("james.martinez")) {
+					t.Errorf("type %d: email leaked", n)
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+	}
+}
+
+// wideType builds a fresh untagged struct type nested depth levels deep.
+func wideType(n, depth int) reflect.Type {
+	fields := make([]reflect.StructField, 24)
+	for i := range fields {
+		fields[i] = reflect.StructField{Name: fmt.Sprintf("F%d_%d_%d", n, depth, i), Type: reflect.TypeFor[string]()}
+		if depth > 0 {
+			fields[i].Type = wideType(n*100+i, depth-1)
+		}
+	}
+	return reflect.StructOf(fields)
+}
+
+func TestDSN(t *testing.T) {
+	for dsn, want := range map[string]string{
+		"postgres://app:s3cret@db:5432/app?sslmode=disable": "postgres://app:xxxxx@db:5432/app?sslmode=disable",
+		"host=db user=app password=s3cret dbname=app":       "host=db user=app password=xxxxx dbname=app",
+		"host=db password='s3 cret' dbname=app":             "host=db password=xxxxx dbname=app",
+		"postgres://db/app?user=app&password=s3cret&x=1":    "postgres://db/app?user=app&password=xxxxx&x=1",
+		"postgres://app@db/app":                             "postgres://app@db/app",
+	} {
+		if got := redact.DSN(dsn); got != want {
+			t.Errorf("DSN(%q) = %q, want %q", dsn, got, want)
+		}
+	}
+}
+
+func TestWriter_ScrubsPatterns(t *testing.T) {
+	s, err := redact.NewScrubber(redact.DefaultPatterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	l := zerolog.New(redact.Writer(zerolog.MultiLevelWriter(&buf), s)).With().Timestamp().Logger()
+	l.Warn().Str("error", "duplicate key (email)=(a.b@example.com)").Int("status", 409).
+		Str("request_id", "01234567-8901-4abc-8def-0123456789ab").
+		Str("phone", "081234567890").
+		Msg("call +62 812-3456-7890 or 0812 3456 7890")
+
+	var ev map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &ev); err != nil {
+		t.Fatalf("scrubbed line is not JSON: %q", buf.String())
+	}
+	if ev["error"] != "duplicate key (email)=([email])" {
+		t.Errorf("error = %q", ev["error"])
+	}
+	if ev["message"] != "call [phone] or [phone]" {
+		t.Errorf("message = %q", ev["message"])
+	}
+	if ev["phone"] != "[phone]" {
+		t.Errorf("phone = %q", ev["phone"])
+	}
+	if ev["request_id"] != "01234567-8901-4abc-8def-0123456789ab" {
+		t.Errorf("request_id = %q, digits inside ids must stay", ev["request_id"])
+	}
+	if ev["status"] != float64(409) || ev["time"] == "" {
+		t.Errorf("other fields changed: %v", ev)
+	}
+}
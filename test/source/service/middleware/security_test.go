@@ -0,0 +1,67 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/i-sub135/go-rest-blueprint/source/config"
+	"github.com/i-sub135/go-rest-blueprint/source/service/middleware"
+)
+
+func corsEngine() *gin.Engine {
+	cfg := &config.Config{}
+	cors := &cfg.HTTP.Security.CORS
+	cors.AllowedOrigins = []string{"https://app.example.com"}
+	cors.AllowedMethods = []string{"GET", "POST"}
+	cors.AllowedHeaders = []string{"Authorization"}
+	cors.MaxAge = 10 * time.Minute
+	cfg.HTTP.Security.ContentTypeNosniff = true
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.SecurityHeaders(cfg), middleware.CORS(cfg))
+	r.GET("/users", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	r := corsEngine()
+
+	req := httptest.NewRequest(http.MethodOptions, "/users", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", w.Code)
+	}
+	h := w.Header()
+	if h.Get("Access-Control-Allow-Origin") != "https://app.example.com" ||
+		h.Get("Access-Control-Allow-Methods") != "GET, POST" ||
+		h.Get("Access-Control-Max-Age") != "600" {
+		t.Errorf("preflight headers = %v", h)
+	}
+	if h.Get("X-Content-Type-Options") != "nosniff" {
+		t.Error("security headers missing on preflight")
+	}
+}
+
+func TestCORS_UnknownOrigin(t *testing.T) {
+	r := corsEngine()
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if v := w.Header().Get("Access-Control-Allow-Origin"); v != "" {
+		t.Errorf("Access-Control-Allow-Origin = %q for an unknown origin", v)
+	}
+}